@@ -58,7 +58,7 @@ func startServer() error {
 	signal.Notify(shutdownSignal, os.Interrupt, syscall.SIGTERM)
 
 	serverCfg := cfg.HTTP.Server
-	apiHandler, err := web.API(log, cfg)
+	apiHandler, limiterStatus, err := web.API(log, cfg)
 	if err != nil {
 		return err
 	}
@@ -73,7 +73,7 @@ func startServer() error {
 	}
 	health := &fasthttp.Server{
 		Name:                          "health",
-		Handler:                       web.Health(log, cfg),
+		Handler:                       web.Health(log, cfg, limiterStatus),
 		TCPKeepalive:                  true,
 		IdleTimeout:                   serverCfg.IdleTimeout,
 		ReadTimeout:                   serverCfg.ReadTimeout,
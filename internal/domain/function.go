@@ -353,3 +353,69 @@ func (f AsQuery) Arguments() []Arg {
 func (f AsQuery) Map(fn func(target interface{}) interface{}) Function {
 	return AsQuery{Value: fn(f.Value)}
 }
+
+// AsForm is a Function that define a `with`
+// parameter as the request body encoded as
+// an URL encoded form.
+type AsForm struct {
+	Value interface{}
+}
+
+// Argument fetches a AsForm argument by name
+func (f AsForm) Argument(name string) Arg {
+	return Arg{}
+}
+
+// SetArgument immutably updates the value of an argument by name
+func (f AsForm) SetArgument(name string, value interface{}) Function {
+	return f
+}
+
+// Target return the value upon which AsForm will be applied.
+func (f AsForm) Target() interface{} {
+	return f.Value
+}
+
+// Arguments return the arguments provided to AsForm function
+func (f AsForm) Arguments() []Arg {
+	return nil
+}
+
+// Map apply the given function to the Target value
+// preserving the AsForm as a wrapper.
+func (f AsForm) Map(fn func(target interface{}) interface{}) Function {
+	return AsForm{Value: fn(f.Value)}
+}
+
+// AsMultipart is a Function that define a `with`
+// parameter as the request body encoded as
+// a multipart form.
+type AsMultipart struct {
+	Value interface{}
+}
+
+// Argument fetches a AsMultipart argument by name
+func (f AsMultipart) Argument(name string) Arg {
+	return Arg{}
+}
+
+// SetArgument immutably updates the value of an argument by name
+func (f AsMultipart) SetArgument(name string, value interface{}) Function {
+	return f
+}
+
+// Target return the value upon which AsMultipart will be applied.
+func (f AsMultipart) Target() interface{} {
+	return f.Value
+}
+
+// Arguments return the arguments provided to AsMultipart function
+func (f AsMultipart) Arguments() []Arg {
+	return nil
+}
+
+// Map apply the given function to the Target value
+// preserving the AsMultipart as a wrapper.
+func (f AsMultipart) Map(fn func(target interface{}) interface{}) Function {
+	return AsMultipart{Value: fn(f.Value)}
+}
@@ -11,6 +11,63 @@ import (
 // the timeout defined in HTTPRequest.
 var ErrRequestTimeout = errors.New("request timed out")
 
+// ErrRateLimited is the error returned by HTTPClient
+// when a HTTP call is not made due to the rate limit
+// defined for the upstream dependency.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// Kinds of failure of a HTTP call made by HTTPClient.
+const (
+	ErrorKindTimeout           = "timeout"
+	ErrorKindConnectionRefused = "connection-refused"
+	ErrorKindConnectionReset   = "connection-reset"
+	ErrorKindDNS               = "dns"
+	ErrorKindTLS               = "tls"
+	ErrorKindRateLimited       = "rate-limited"
+	ErrorKindCancelled         = "cancelled"
+	ErrorKindInvalidJSON       = "invalid-json"
+	ErrorKindDecompression     = "decompression"
+	ErrorKindUnknown           = "unknown"
+)
+
+// RequestError is the error returned by HTTPClient
+// when a HTTP call fails, identifying the kind of
+// failure.
+type RequestError struct {
+	Kind string
+	Err  error
+}
+
+// NewRequestError constructs a RequestError.
+func NewRequestError(kind string, err error) error {
+	return &RequestError{Kind: kind, Err: err}
+}
+
+func (e *RequestError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorKindOf returns the kind of failure
+// of an error returned by HTTPClient.
+func ErrorKindOf(err error) string {
+	var re *RequestError
+	switch {
+	case errors.As(err, &re):
+		return re.Kind
+	case errors.Is(err, ErrRequestTimeout):
+		return ErrorKindTimeout
+	case errors.Is(err, ErrRateLimited):
+		return ErrorKindRateLimited
+	default:
+		return ErrorKindUnknown
+	}
+}
+
 // EnvSource expose access to environment variables.
 type EnvSource interface {
 	GetString(key string) string
@@ -24,3 +81,24 @@ type EnvSource interface {
 type HTTPClient interface {
 	Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error)
 }
+
+type requestTargetCtxKey struct{}
+
+// RequestTarget identifies the mapped resource and tenant
+// on behalf of which an HTTP call is made.
+type RequestTarget struct {
+	Tenant   string
+	Resource string
+}
+
+// WithRequestTarget returns a copy of the given Context
+// carrying the RequestTarget of the HTTP call.
+func WithRequestTarget(ctx context.Context, target RequestTarget) context.Context {
+	return context.WithValue(ctx, requestTargetCtxKey{}, target)
+}
+
+// GetRequestTarget returns the RequestTarget carried by the given Context.
+func GetRequestTarget(ctx context.Context) (RequestTarget, bool) {
+	target, ok := ctx.Value(requestTargetCtxKey{}).(RequestTarget)
+	return target, ok
+}
@@ -2,11 +2,13 @@ package domain
 
 // Methods available to be used in query statements.
 const (
-	FromMethod   string = "from"
-	ToMethod     string = "to"
-	IntoMethod   string = "into"
-	UpdateMethod string = "update"
-	DeleteMethod string = "delete"
+	FromMethod    string = "from"
+	ToMethod      string = "to"
+	IntoMethod    string = "into"
+	UpdateMethod  string = "update"
+	DeleteMethod  string = "delete"
+	CheckMethod   string = "check"
+	OptionsMethod string = "options"
 )
 
 // Query is the internal representation of the restQL language.
@@ -20,18 +22,22 @@ type Modifiers map[string]interface{}
 
 // Statement is the internal representation of a query statement.
 type Statement struct {
-	Method       string
-	Resource     string
-	Alias        string
-	In           []string
-	DependsOn    DependsOn
-	Headers      map[string]interface{}
-	Timeout      interface{}
-	With         Params
-	Only         []interface{}
-	Hidden       bool
-	CacheControl CacheControl
-	IgnoreErrors bool
+	Method             string
+	Resource           string
+	Alias              string
+	In                 []string
+	DependsOn          DependsOn
+	Headers            map[string]interface{}
+	Timeout            interface{}
+	Hedge              interface{}
+	With               Params
+	Only               []interface{}
+	Hidden             bool
+	CacheControl       CacheControl
+	IgnoreErrors       bool
+	IgnoreErrorsStatus []int
+	SuccessOn          []int
+	Raw                bool
 }
 
 // Params is the internal representation of the `with` clause.
@@ -40,10 +46,19 @@ type Params struct {
 	Values map[string]interface{}
 }
 
-// CacheControl is the internal representation of the `max-age` and `s-max-age` clauses.
+// CacheControl is the internal representation of the cache directives clauses:
+// `max-age`, `s-max-age`, `stale-while-revalidate`, `stale-if-error`,
+// `no-cache`, `no-store`, `private`, `public` and `must-revalidate`.
 type CacheControl struct {
-	MaxAge  interface{}
-	SMaxAge interface{}
+	MaxAge               interface{}
+	SMaxAge              interface{}
+	StaleWhileRevalidate interface{}
+	StaleIfError         interface{}
+	NoCache              bool
+	NoStore              bool
+	Private              bool
+	Public               bool
+	MustRevalidate       bool
 }
 
 // Variable is the internal representation of a variable parameter value.
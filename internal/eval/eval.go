@@ -38,3 +38,8 @@ var ErrTimeout = errors.New("timeout")
 // ErrMapping is returned by Evaluator when
 // the asked query references a non existing mapping.
 var ErrMapping = errors.New("unknown mappings")
+
+// ErrMissingVariables is returned by Evaluator when
+// the asked query uses variables without value
+// as path parameters.
+var ErrMissingVariables = errors.New("missing variables")
@@ -45,9 +45,11 @@ func NewEvaluator(log restql.Logger, mr MappingsReader, qr QueryReader, r runner
 
 // AdHocQuery executes an ad-hoc send by the client with
 // the options and HTTP information.
-func (e Evaluator) AdHocQuery(ctx context.Context, queryTxt string, queryOpts restql.QueryOptions, queryInput restql.QueryInput) (domain.Resources, error) {
+// It returns the query internal representation alongside its result,
+// which includes the hidden statements, to be removed by ApplyHidden.
+func (e Evaluator) AdHocQuery(ctx context.Context, queryTxt string, queryOpts restql.QueryOptions, queryInput restql.QueryInput) (domain.Query, domain.Resources, error) {
 	if queryOpts.Tenant == "" {
-		return nil, fmt.Errorf("%w: %s", ErrValidation, errInvalidTenant)
+		return domain.Query{}, nil, fmt.Errorf("%w: %s", ErrValidation, errInvalidTenant)
 	}
 
 	return e.evaluateQuery(ctx, queryTxt, queryOpts, queryInput)
@@ -56,15 +58,17 @@ func (e Evaluator) AdHocQuery(ctx context.Context, queryTxt string, queryOpts re
 // SavedQuery executes a saved query identified by namespace,
 // id and revision with the options and HTTP information
 // send by the client.
-func (e Evaluator) SavedQuery(ctx context.Context, queryOpts restql.QueryOptions, queryInput restql.QueryInput) (domain.Resources, error) {
+// It returns the query internal representation alongside its result,
+// which includes the hidden statements, to be removed by ApplyHidden.
+func (e Evaluator) SavedQuery(ctx context.Context, queryOpts restql.QueryOptions, queryInput restql.QueryInput) (domain.Query, domain.Resources, error) {
 	err := validateQueryOptions(queryOpts)
 	if err != nil {
-		return nil, err
+		return domain.Query{}, nil, err
 	}
 
 	savedQuery, err := e.queryReader.Get(ctx, queryOpts.Namespace, queryOpts.Id, queryOpts.Revision)
 	if err != nil {
-		return nil, err
+		return domain.Query{}, nil, err
 	}
 
 	log := restql.GetLogger(ctx)
@@ -73,25 +77,25 @@ func (e Evaluator) SavedQuery(ctx context.Context, queryOpts restql.QueryOptions
 	return e.evaluateQuery(ctx, savedQuery.Text, queryOpts, queryInput)
 }
 
-func (e Evaluator) evaluateQuery(ctx context.Context, queryTxt string, queryOpts restql.QueryOptions, queryInput restql.QueryInput) (domain.Resources, error) {
+func (e Evaluator) evaluateQuery(ctx context.Context, queryTxt string, queryOpts restql.QueryOptions, queryInput restql.QueryInput) (domain.Query, domain.Resources, error) {
 	log := restql.GetLogger(ctx)
 
 	query, err := e.parser.Parse(queryTxt)
 	if err != nil {
 		log.Debug("failed to parse query", "error", err)
-		return nil, fmt.Errorf("%w: invalid query syntax %s", ErrParser, err)
+		return domain.Query{}, nil, syntaxError{err: err}
 	}
 
 	mappings, err := e.mappingsReader.FromTenant(ctx, queryOpts.Tenant)
 	if err != nil {
 		log.Error("failed to fetch mappings", err)
-		return nil, err
+		return domain.Query{}, nil, err
 	}
 
 	err = validateQueryResources(query, mappings)
 	if err != nil {
 		log.Error("query reference invalid resource", err, "mappings", fmt.Sprintf("%#v", mappings))
-		return nil, err
+		return domain.Query{}, nil, err
 	}
 
 	queryContext := restql.QueryContext{
@@ -100,6 +104,12 @@ func (e Evaluator) evaluateQuery(ctx context.Context, queryTxt string, queryOpts
 		Input:    queryInput,
 	}
 
+	err = ValidatePathVariables(query, mappings, queryInput)
+	if err != nil {
+		log.Debug("query uses missing variables as path parameters", "error", err)
+		return domain.Query{}, nil, err
+	}
+
 	queryCtx := e.lifecycle.BeforeQuery(ctx, queryTxt, queryContext)
 
 	query = ResolveVariables(query, queryContext.Input)
@@ -107,36 +117,38 @@ func (e Evaluator) evaluateQuery(ctx context.Context, queryTxt string, queryOpts
 	resources, err := e.runner.ExecuteQuery(queryCtx, query, queryContext)
 	switch {
 	case err == runner.ErrQueryTimedOut:
-		return nil, fmt.Errorf("%w: %s", ErrTimeout, err)
+		return domain.Query{}, nil, fmt.Errorf("%w: %s", ErrTimeout, err)
 	case errors.Is(err, runner.ErrInvalidChainedParameter):
-		return nil, fmt.Errorf("%w: %s", ErrParser, err)
+		return domain.Query{}, nil, fmt.Errorf("%w: %s", ErrParser, err)
 	case errors.Is(err, runner.ErrInvalidDependsOnTarget):
-		return nil, fmt.Errorf("%w: %s", ErrParser, err)
+		return domain.Query{}, nil, fmt.Errorf("%w: %s", ErrParser, err)
 	case err != nil:
-		return nil, err
+		return domain.Query{}, nil, err
 	}
 
 	resources, err = ApplyFilters(log, query, resources)
 	if err != nil {
 		log.Error("failed to apply filters", err, "input", fmt.Sprintf("%+#v", queryContext.Input))
-		return nil, err
+		return domain.Query{}, nil, err
 	}
 
 	resources = ApplyAggregators(log, query, resources)
 
 	e.lifecycle.AfterQuery(queryCtx, queryTxt, resources)
 
-	resources = ApplyHidden(query, resources)
-
-	return resources, nil
+	return query, resources, nil
 }
 
 func validateQueryResources(query domain.Query, mappings map[string]restql.Mapping) error {
 	for _, s := range query.Statements {
-		_, found := mappings[s.Resource]
+		mapping, found := mappings[s.Resource]
 		if !found {
 			return fmt.Errorf("%w: statement should reference a valid mapped resource. Error was in %s", ErrMapping, s.Resource)
 		}
+
+		if !mapping.AllowsMethod(s.Method) {
+			return fmt.Errorf("%w: method %s is not allowed on resource %s", ErrValidation, s.Method, s.Resource)
+		}
 	}
 
 	return nil
@@ -161,3 +173,21 @@ func validateQueryOptions(queryOpts restql.QueryOptions) error {
 
 	return nil
 }
+
+// syntaxError identifies a query with invalid syntax
+// as ErrParser while keeping the parser failure reachable.
+type syntaxError struct {
+	err error
+}
+
+func (se syntaxError) Error() string {
+	return fmt.Sprintf("%s: invalid query syntax %s", ErrParser, se.err)
+}
+
+func (se syntaxError) Is(target error) bool {
+	return target == ErrParser
+}
+
+func (se syntaxError) Unwrap() error {
+	return se.err
+}
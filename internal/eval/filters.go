@@ -42,8 +42,9 @@ func applyOnlyFilters(filters []interface{}, resourceResult interface{}) (interf
 
 	switch resourceResult := resourceResult.(type) {
 	case restql.DoneResource:
-		body := resourceResult.ResponseBody.Unmarshal()
-		result, err := extractUsingFilters(buildFilterTree(filters), body)
+		filterTree := buildFilterTree(filters)
+		body := extractFilteredFields(resourceResult.ResponseBody, filterTree)
+		result, err := extractUsingFilters(filterTree, body)
 		if err != nil {
 			return nil, err
 		}
@@ -61,6 +62,28 @@ func applyOnlyFilters(filters []interface{}, resourceResult interface{}) (interf
 	}
 }
 
+// extractFilteredFields returns the response body content with only the
+// top level fields referenced by the filters, avoiding the parsing of the
+// ones that would be discarded. When all fields are selected or the
+// content is not an object, it is returned entirely.
+func extractFilteredFields(body *restql.ResponseBody, filters map[string]interface{}) interface{} {
+	if _, hasSelectAll := filters["*"]; hasSelectAll {
+		return body.Unmarshal()
+	}
+
+	fields := make([]string, 0, len(filters))
+	for f := range filters {
+		fields = append(fields, f)
+	}
+
+	obj, ok := body.ExtractFields(fields)
+	if !ok {
+		return body.Unmarshal()
+	}
+
+	return obj
+}
+
 func extractUsingFilters(filters map[string]interface{}, resourceResult interface{}) (interface{}, error) {
 	filters, hasSelectAll := removeSelectAllFilter(filters)
 
@@ -578,3 +578,15 @@ func TestOnlyFilters(t *testing.T) {
 		})
 	}
 }
+
+func TestOnlyFiltersOnRawBody(t *testing.T) {
+	query := domain.Query{Statements: []domain.Statement{{Resource: "hero", Only: []interface{}{[]string{"name"}, []string{"partner", "name"}}}}}
+	body := restql.NewResponseBodyFromBytes(test.NoOpLogger, []byte(`{"id": 1, "name": "batman", "partner": {"id": 2, "name": "robin"}, "villains": [{"name": "joker"}]}`))
+	resources := domain.Resources{"hero": restql.DoneResource{ResponseBody: body}}
+
+	got, err := eval.ApplyFilters(test.NoOpLogger, query, resources)
+	test.VerifyError(t, err)
+
+	hero := got["hero"].(restql.DoneResource)
+	test.Equal(t, hero.ResponseBody.Unmarshal(), test.Unmarshal(`{"name": "batman", "partner": {"name": "robin"}}`))
+}
@@ -2,9 +2,12 @@ package eval
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
 )
@@ -19,6 +22,7 @@ func ResolveVariables(query domain.Query, input restql.QueryInput) domain.Query
 		copyStmt := stmt
 		copyStmt.With = resolveWith(copyStmt.With, input)
 		copyStmt.Timeout = resolveTimeout(copyStmt.Timeout, input)
+		copyStmt.Hedge = resolveTimeout(copyStmt.Hedge, input)
 		copyStmt.Headers = resolveHeaders(copyStmt.Headers, input)
 		copyStmt.CacheControl = resolveCacheControl(copyStmt.CacheControl, input)
 		copyStmt.Only = resolveOnly(copyStmt.Only, input)
@@ -29,6 +33,65 @@ func ResolveVariables(query domain.Query, input restql.QueryInput) domain.Query
 	return domain.Query{Use: query.Use, Statements: result}
 }
 
+// MissingVariablesError identifies a query with variables used as
+// path parameters that have no value on the client input.
+type MissingVariablesError struct {
+	Variables []string
+}
+
+func (mve MissingVariablesError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrMissingVariables, strings.Join(mve.Variables, ", "))
+}
+
+func (mve MissingVariablesError) Is(target error) bool {
+	return target == ErrMissingVariables
+}
+
+// ValidatePathVariables returns a MissingVariablesError if any
+// variable used as a path parameter cannot be resolved, since
+// the request would be sent to an incomplete URL.
+func ValidatePathVariables(query domain.Query, mappings map[string]restql.Mapping, input restql.QueryInput) error {
+	var missing []string
+	seen := make(map[string]bool)
+
+	for _, stmt := range query.Statements {
+		mapping := mappings[stmt.Resource]
+		for key, value := range stmt.With.Values {
+			if !mapping.IsPathParam(key) {
+				continue
+			}
+
+			variable, ok := unwrapVariable(value)
+			if !ok || seen[variable.Target] {
+				continue
+			}
+
+			if _, found := getUniqueParamValue(variable.Target, input); !found {
+				seen[variable.Target] = true
+				missing = append(missing, variable.Target)
+			}
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	sort.Strings(missing)
+	return MissingVariablesError{Variables: missing}
+}
+
+func unwrapVariable(value interface{}) (domain.Variable, bool) {
+	switch value := value.(type) {
+	case domain.Variable:
+		return value, true
+	case domain.Function:
+		return unwrapVariable(value.Target())
+	default:
+		return domain.Variable{}, false
+	}
+}
+
 func resolveWith(with domain.Params, input restql.QueryInput) domain.Params {
 	if with.Values == nil && with.Body == nil {
 		return with
@@ -144,43 +207,50 @@ func resolveComplexWithParam(object map[string]interface{}, input restql.QueryIn
 }
 
 func resolveCacheControl(cacheControl domain.CacheControl, input restql.QueryInput) domain.CacheControl {
-	var result domain.CacheControl
+	result := domain.CacheControl{
+		NoCache:        cacheControl.NoCache,
+		NoStore:        cacheControl.NoStore,
+		Private:        cacheControl.Private,
+		Public:         cacheControl.Public,
+		MustRevalidate: cacheControl.MustRevalidate,
+	}
 
-	switch value := cacheControl.MaxAge.(type) {
-	case domain.Variable:
-		paramValue, found := getUniqueParamValue(value.Target, input)
-		if !found {
-			result.MaxAge = nil
-		}
+	if maxAge, ok := resolveCacheControlValue(cacheControl.MaxAge, input); ok {
+		result.MaxAge = maxAge
+	}
 
-		maxAge, ok := castToInt(paramValue)
-		if !ok {
-			return domain.CacheControl{}
-		}
+	if sMaxAge, ok := resolveCacheControlValue(cacheControl.SMaxAge, input); ok {
+		result.SMaxAge = sMaxAge
+	}
 
-		result.MaxAge = maxAge
-	case int:
-		result.MaxAge = value
+	if staleWhileRevalidate, ok := resolveCacheControlValue(cacheControl.StaleWhileRevalidate, input); ok {
+		result.StaleWhileRevalidate = staleWhileRevalidate
 	}
 
-	switch value := cacheControl.SMaxAge.(type) {
+	if staleIfError, ok := resolveCacheControlValue(cacheControl.StaleIfError, input); ok {
+		result.StaleIfError = staleIfError
+	}
+
+	return result
+}
+
+// resolveCacheControlValue returns the value of a time directive, which
+// is dropped when it is a variable that is missing or is not an integer,
+// keeping the other directives of the statement.
+func resolveCacheControlValue(value interface{}, input restql.QueryInput) (interface{}, bool) {
+	switch value := value.(type) {
 	case domain.Variable:
 		paramValue, found := getUniqueParamValue(value.Target, input)
 		if !found {
-			result.SMaxAge = nil
+			return nil, false
 		}
 
-		smaxAge, ok := castToInt(paramValue)
-		if !ok {
-			return domain.CacheControl{}
-		}
-
-		result.SMaxAge = smaxAge
+		return castToInt(paramValue)
 	case int:
-		result.SMaxAge = value
+		return value, true
+	default:
+		return nil, false
 	}
-
-	return result
 }
 
 func resolveHeaders(headers map[string]interface{}, input restql.QueryInput) map[string]interface{} {
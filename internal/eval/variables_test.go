@@ -183,6 +183,24 @@ func TestResolveVariables(t *testing.T) {
 				Statements: []domain.Statement{{Method: "from", Resource: "hero", CacheControl: domain.CacheControl{MaxAge: 200, SMaxAge: 400}}},
 			},
 		},
+		{
+			"drop only unresolved cache directives",
+			domain.Query{
+				Statements: []domain.Statement{{Method: "from", Resource: "hero", CacheControl: domain.CacheControl{
+					MaxAge:               domain.Variable{"max-age"},
+					SMaxAge:              domain.Variable{"s-max-age"},
+					StaleWhileRevalidate: domain.Variable{"stale"},
+					StaleIfError:         domain.Variable{"stale-if-error"},
+					Private:              true,
+					NoStore:              true,
+					NoCache:              true,
+				}}},
+			},
+			restql.QueryInput{Params: map[string]interface{}{"max-age": "200", "s-max-age": "invalid"}},
+			domain.Query{
+				Statements: []domain.Statement{{Method: "from", Resource: "hero", CacheControl: domain.CacheControl{MaxAge: 200, Private: true, NoStore: true, NoCache: true}}},
+			},
+		},
 		{
 			"resolve variable in headers from params",
 			domain.Query{
@@ -282,3 +300,56 @@ func TestResolveVariables(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePathVariables(t *testing.T) {
+	hero, err := restql.NewMapping("hero", "http://hero.api/:universe/hero/:id")
+	test.VerifyError(t, err)
+	mappings := map[string]restql.Mapping{"hero": hero}
+
+	tests := []struct {
+		name     string
+		query    domain.Query
+		input    restql.QueryInput
+		expected error
+	}{
+		{
+			"no error when path variables are present",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", With: domain.Params{Values: map[string]interface{}{
+				"id":       domain.Variable{"id"},
+				"universe": domain.Flatten{Value: domain.Variable{"universe"}},
+			}}}}},
+			restql.QueryInput{Params: map[string]interface{}{"id": "1"}, Headers: map[string]string{"Universe": "dc"}},
+			nil,
+		},
+		{
+			"no error when missing variables are not path parameters",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", With: domain.Params{Values: map[string]interface{}{
+				"id":   "1",
+				"name": domain.Variable{"name"},
+			}}}}},
+			restql.QueryInput{},
+			nil,
+		},
+		{
+			"error with missing path variables",
+			domain.Query{Statements: []domain.Statement{
+				{Method: "from", Resource: "hero", With: domain.Params{Values: map[string]interface{}{
+					"id":       domain.Variable{"id"},
+					"universe": domain.Flatten{Value: domain.Variable{"universe"}},
+				}}},
+				{Method: "from", Resource: "hero", Alias: "other", With: domain.Params{Values: map[string]interface{}{
+					"id": domain.Variable{"id"},
+				}}},
+			}},
+			restql.QueryInput{},
+			eval.MissingVariablesError{Variables: []string{"id", "universe"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := eval.ValidatePathVariables(tt.query, mappings, tt.input)
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
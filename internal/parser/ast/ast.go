@@ -2,27 +2,44 @@ package ast
 
 // restQL language keywords.
 const (
-	FromMethod          = "from"
-	IntoMethod          = "into"
-	UpdateMethod        = "update"
-	ToMethod            = "to"
-	DeleteMethod        = "delete"
-	WithKeyword         = "with"
-	OnlyKeyword         = "only"
-	HeadersKeyword      = "headers"
-	HiddenKeyword       = "hidden"
-	TimeoutKeyword      = "timeout"
-	MaxAgeKeyword       = "max-age"
-	SmaxAgeKeyword      = "s-max-age"
-	IgnoreErrorsKeyword = "ignore-errors"
-	Matches             = "matches"
-	NoMultiplex         = "no-multiplex"
-	Base64              = "base64"
-	JSON                = "json"
-	AsBody              = "as-body"
-	Flatten             = "flatten"
-	NoExplode           = "no-explode"
-	AsQuery             = "as-query"
+	FromMethod                  = "from"
+	IntoMethod                  = "into"
+	UpdateMethod                = "update"
+	ToMethod                    = "to"
+	DeleteMethod                = "delete"
+	CheckMethod                 = "check"
+	OptionsMethod               = "options"
+	WithKeyword                 = "with"
+	OnlyKeyword                 = "only"
+	HeadersKeyword              = "headers"
+	HiddenKeyword               = "hidden"
+	TimeoutKeyword              = "timeout"
+	HedgeKeyword                = "hedge"
+	MaxAgeKeyword               = "max-age"
+	SmaxAgeKeyword              = "s-max-age"
+	StaleWhileRevalidateKeyword = "stale-while-revalidate"
+	StaleIfErrorKeyword         = "stale-if-error"
+	PrivateKeyword              = "private"
+	PublicKeyword               = "public"
+	NoStoreKeyword              = "no-store"
+	NoCacheKeyword              = "no-cache"
+	MustRevalidateKeyword       = "must-revalidate"
+	IgnoreErrorsKeyword         = "ignore-errors"
+	SuccessOnKeyword            = "success-on"
+	RawKeyword                  = "raw"
+	FailFastKeyword             = "fail-fast"
+	StatusFromKeyword           = "status-from"
+	StatusStrategyKeyword       = "status-strategy"
+	Matches                     = "matches"
+	NoMultiplex                 = "no-multiplex"
+	Base64                      = "base64"
+	JSON                        = "json"
+	AsBody                      = "as-body"
+	Flatten                     = "flatten"
+	NoExplode                   = "no-explode"
+	AsQuery                     = "as-query"
+	AsForm                      = "as-form"
+	AsMultipart                 = "as-multipart"
 )
 
 // Query is the root of the restQL AST.
@@ -40,8 +57,9 @@ type Use struct {
 // UseValue is the syntax node representing
 // the `use` clause possible values.
 type UseValue struct {
-	Int    *int
-	String *string
+	Int     *int
+	String  *string
+	Boolean *bool
 }
 
 // Block is the syntax node representing a statement.
@@ -54,18 +72,27 @@ type Block struct {
 }
 
 // Qualifier is the syntax node representing statement
-// clauses: `with`, `only`, `hidden`, `headers`, `timeout`
-// `max-age`, `s-max-age` and `ignore-errors`.
+// clauses: `with`, `only`, `hidden`, `headers`, `timeout`,
+// `hedge after`, `max-age`, `s-max-age`, `stale-while-revalidate`,
+// `stale-if-error`, cache directives flags, `ignore-errors`,
+// `success-on` and `raw`.
 type Qualifier struct {
-	With         *Parameters
-	Only         []Filter
-	Headers      []HeaderItem
-	DependsOn    string
-	Hidden       bool
-	Timeout      *TimeoutValue
-	MaxAge       *MaxAgeValue
-	SMaxAge      *SMaxAgeValue
-	IgnoreErrors bool
+	With                 *Parameters
+	Only                 []Filter
+	Headers              []HeaderItem
+	DependsOn            string
+	Hidden               bool
+	Timeout              *TimeoutValue
+	Hedge                *HedgeValue
+	MaxAge               *MaxAgeValue
+	SMaxAge              *SMaxAgeValue
+	StaleWhileRevalidate *StaleWhileRevalidateValue
+	StaleIfError         *StaleIfErrorValue
+	CacheFlag            string
+	IgnoreErrors         bool
+	IgnoreErrorsStatus   []int
+	SuccessOn            []int
+	Raw                  bool
 }
 
 // Filter is the syntax node representing entries
@@ -173,6 +200,10 @@ type variableOrInt struct {
 // the value in the `timeout` clause.
 type TimeoutValue variableOrInt
 
+// HedgeValue is the syntax node representing
+// the value in the `hedge after` clause.
+type HedgeValue variableOrInt
+
 // MaxAgeValue is the syntax node representing
 // the value in the `max-age` clause.
 type MaxAgeValue variableOrInt
@@ -181,6 +212,14 @@ type MaxAgeValue variableOrInt
 // the value in the `s-max-age` clause.
 type SMaxAgeValue variableOrInt
 
+// StaleWhileRevalidateValue is the syntax node representing
+// the value in the `stale-while-revalidate` clause.
+type StaleWhileRevalidateValue variableOrInt
+
+// StaleIfErrorValue is the syntax node representing
+// the value in the `stale-if-error` clause.
+type StaleIfErrorValue variableOrInt
+
 // DependsOnValue is the syntax node representing
 // the value in the `depends-on` clause.
 type DependsOnValue string
@@ -200,9 +239,47 @@ const noFilename = ""
 func (g Generator) Parse(query string) (*Query, error) {
 	parse, err := Parse(noFilename, []byte(query))
 	if err != nil {
-		return nil, err
+		return nil, newSyntaxError(err)
 	}
 
 	q := parse.(Query)
 	return &q, nil
 }
+
+// SyntaxError describes where the query text
+// stops complying with the restQL grammar.
+type SyntaxError struct {
+	Line     int
+	Column   int
+	Offset   int
+	Expected []string
+	err      error
+}
+
+func (se SyntaxError) Error() string {
+	return se.err.Error()
+}
+
+func (se SyntaxError) Unwrap() error {
+	return se.err
+}
+
+func newSyntaxError(err error) error {
+	list, ok := err.(errList)
+	if !ok || len(list) == 0 {
+		return err
+	}
+
+	pe, ok := list[0].(*parserError)
+	if !ok {
+		return err
+	}
+
+	return SyntaxError{
+		Line:     pe.pos.line,
+		Column:   pe.pos.col,
+		Offset:   pe.pos.offset,
+		Expected: pe.expected,
+		err:      err,
+	}
+}
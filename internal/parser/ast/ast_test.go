@@ -1,6 +1,7 @@
 package ast_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/parser/ast"
@@ -34,6 +35,16 @@ func TestAstGenerator(t *testing.T) {
 			"from cart",
 			ast.Query{Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "cart"}}},
 		},
+		{
+			"Simple check resource query",
+			"check cart",
+			ast.Query{Blocks: []ast.Block{{Method: ast.CheckMethod, Resource: "cart"}}},
+		},
+		{
+			"Simple options resource query",
+			"options cart",
+			ast.Query{Blocks: []ast.Block{{Method: ast.OptionsMethod, Resource: "cart"}}},
+		},
 		{
 			"Simple from resource query with comment",
 			`// a comment
@@ -58,6 +69,54 @@ func TestAstGenerator(t *testing.T) {
 				Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "cart"}},
 			},
 		},
+		{
+			"Simple from resource query with cache directives use modifier",
+			`
+							use stale-while-revalidate 30
+							use stale-if-error 600
+							use private
+							use must-revalidate
+
+							from cart
+					`,
+			ast.Query{
+				Use: []ast.Use{
+					{Key: ast.StaleWhileRevalidateKeyword, Value: ast.UseValue{Int: Int(30)}},
+					{Key: ast.StaleIfErrorKeyword, Value: ast.UseValue{Int: Int(600)}},
+					{Key: ast.PrivateKeyword, Value: ast.UseValue{Boolean: Boolean(true)}},
+					{Key: ast.MustRevalidateKeyword, Value: ast.UseValue{Boolean: Boolean(true)}},
+				},
+				Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "cart"}},
+			},
+		},
+		{
+			"Simple from resource query with fail-fast use modifier",
+			`
+							use fail-fast
+
+							from cart
+					`,
+			ast.Query{
+				Use:    []ast.Use{{Key: ast.FailFastKeyword, Value: ast.UseValue{Boolean: Boolean(true)}}},
+				Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "cart"}},
+			},
+		},
+		{
+			"Simple from resource query with status use modifiers",
+			`
+							use status-from cart
+							use status-strategy first-failure
+
+							from cart
+					`,
+			ast.Query{
+				Use: []ast.Use{
+					{Key: ast.StatusFromKeyword, Value: ast.UseValue{String: String("cart")}},
+					{Key: ast.StatusStrategyKeyword, Value: ast.UseValue{String: String("first-failure")}},
+				},
+				Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "cart"}},
+			},
+		},
 		{
 			"query with two from statements",
 			`
@@ -595,6 +654,26 @@ func TestAstGenerator(t *testing.T) {
 			"from hero ignore-errors",
 			ast.Query{Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "hero", Qualifiers: []ast.Qualifier{{IgnoreErrors: true}}}}},
 		},
+		{
+			"Get query with ignore errors flag for status codes",
+			"from hero ignore-errors 404, 409",
+			ast.Query{Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "hero", Qualifiers: []ast.Qualifier{{IgnoreErrors: true, IgnoreErrorsStatus: []int{404, 409}}}}}},
+		},
+		{
+			"Get query with success on flag",
+			"from hero success-on 404",
+			ast.Query{Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "hero", Qualifiers: []ast.Qualifier{{SuccessOn: []int{404}}}}}},
+		},
+		{
+			"Get query with ignore errors and success on flags",
+			"from hero ignore-errors 409, success-on 404, 410",
+			ast.Query{Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "hero", Qualifiers: []ast.Qualifier{{IgnoreErrors: true, IgnoreErrorsStatus: []int{409}}, {SuccessOn: []int{404, 410}}}}}},
+		},
+		{
+			"Get query with raw flag",
+			"from hero raw",
+			ast.Query{Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "hero", Qualifiers: []ast.Qualifier{{Raw: true}}}}},
+		},
 		{
 			"Get query with integer timeout",
 			`from hero timeout 200`,
@@ -605,6 +684,16 @@ func TestAstGenerator(t *testing.T) {
 			`from hero timeout $some-time`,
 			ast.Query{Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "hero", Qualifiers: []ast.Qualifier{{Timeout: &ast.TimeoutValue{Variable: String("some-time")}}}}}},
 		},
+		{
+			"Get query with integer hedge delay",
+			`from hero hedge after 50`,
+			ast.Query{Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "hero", Qualifiers: []ast.Qualifier{{Hedge: &ast.HedgeValue{Int: Int(50)}}}}}},
+		},
+		{
+			"Get query with variable hedge delay",
+			`from hero timeout 200 hedge after $delay`,
+			ast.Query{Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "hero", Qualifiers: []ast.Qualifier{{Timeout: &ast.TimeoutValue{Int: Int(200)}}, {Hedge: &ast.HedgeValue{Variable: String("delay")}}}}}},
+		},
 		{
 			"Get query with headers",
 			`from hero headers Authorization = "abcdef12345", X-Trace-Id = $trace-id, Basic-Auth = done-resource.auth`,
@@ -624,6 +713,28 @@ func TestAstGenerator(t *testing.T) {
 			`from hero s-max-age 2000`,
 			ast.Query{Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "hero", Qualifiers: []ast.Qualifier{{SMaxAge: &ast.SMaxAgeValue{Int: Int(2000)}}}}}},
 		},
+		{
+			"Get query with stale directives",
+			`from hero stale-while-revalidate 30 stale-if-error $staleIfError`,
+			ast.Query{Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "hero", Qualifiers: []ast.Qualifier{
+				{StaleWhileRevalidate: &ast.StaleWhileRevalidateValue{Int: Int(30)}},
+				{StaleIfError: &ast.StaleIfErrorValue{Variable: String("staleIfError")}},
+			}}}},
+		},
+		{
+			"Get query with cache directives flags",
+			`from hero
+				max-age 60
+				public
+				must-revalidate
+				no-store`,
+			ast.Query{Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "hero", Qualifiers: []ast.Qualifier{
+				{MaxAge: &ast.MaxAgeValue{Int: Int(60)}},
+				{CacheFlag: ast.PublicKeyword},
+				{CacheFlag: ast.MustRevalidateKeyword},
+				{CacheFlag: ast.NoStoreKeyword},
+			}}}},
+		},
 		{
 			"Simple from resource query with aggregation",
 			`
@@ -992,3 +1103,37 @@ func TestAstGenerator(t *testing.T) {
 		})
 	}
 }
+
+func TestAstGeneratorSyntaxError(t *testing.T) {
+	type position struct {
+		Line   int
+		Column int
+		Offset int
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected position
+	}{
+		{"unknown statement method", "form hero", position{Line: 1, Column: 1, Offset: 0}},
+		{"incomplete with clause", "from hero with", position{Line: 1, Column: 15, Offset: 14}},
+	}
+
+	generator, err := ast.New()
+
+	test.VerifyError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := generator.Parse(tt.query)
+
+			var syntaxErr ast.SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("expected syntax error, got: %v", err)
+			}
+
+			test.Equal(t, position{Line: syntaxErr.Line, Column: syntaxErr.Column, Offset: syntaxErr.Offset}, tt.expected)
+		})
+	}
+}
@@ -60,6 +60,13 @@ func newUse(rule, value interface{}) (Use, error) {
 	return Use{Key: r, Value: v}, nil
 }
 
+func newUseFlag(flag interface{}) (Use, error) {
+	f := flag.(string)
+	enabled := true
+
+	return Use{Key: f, Value: UseValue{Boolean: &enabled}}, nil
+}
+
 func newUseValue(value interface{}) (UseValue, error) {
 	vInt, ok := value.(int)
 	if ok {
@@ -74,7 +81,7 @@ func newUseValue(value interface{}) (UseValue, error) {
 	return UseValue{}, errors.Errorf("unknown use value type : %T", value)
 }
 
-func newBlock(action, modifiers, with, filter, ignore interface{}) (Block, error) {
+func newBlock(action, modifiers, with, filter, flags interface{}) (Block, error) {
 	ac := action.(actionRule)
 	block := Block{
 		Method:   ac.Method,
@@ -94,10 +101,18 @@ func newBlock(action, modifiers, with, filter, ignore interface{}) (Block, error
 				q = Qualifier{Headers: m}
 			case *TimeoutValue:
 				q = Qualifier{Timeout: m}
+			case *HedgeValue:
+				q = Qualifier{Hedge: m}
 			case *MaxAgeValue:
 				q = Qualifier{MaxAge: m}
 			case *SMaxAgeValue:
 				q = Qualifier{SMaxAge: m}
+			case *StaleWhileRevalidateValue:
+				q = Qualifier{StaleWhileRevalidate: m}
+			case *StaleIfErrorValue:
+				q = Qualifier{StaleIfError: m}
+			case cacheFlag:
+				q = Qualifier{CacheFlag: string(m)}
 			case DependsOnValue:
 				q = Qualifier{DependsOn: string(m)}
 			default:
@@ -130,11 +145,23 @@ func newBlock(action, modifiers, with, filter, ignore interface{}) (Block, error
 		block.Qualifiers = append(block.Qualifiers, q)
 	}
 
-	if ignore != nil {
-		ig := ignore.(ignoreErrors)
-		q := Qualifier{IgnoreErrors: bool(ig)}
+	if flags != nil {
+		for _, f := range flags.([]interface{}) {
+			var q Qualifier
 
-		block.Qualifiers = append(block.Qualifiers, q)
+			switch f := f.(type) {
+			case ignoreErrors:
+				q = Qualifier{IgnoreErrors: true, IgnoreErrorsStatus: f.status}
+			case successOn:
+				q = Qualifier{SuccessOn: f}
+			case raw:
+				q = Qualifier{Raw: true}
+			default:
+				continue
+			}
+
+			block.Qualifiers = append(block.Qualifiers, q)
+		}
 	}
 
 	return block, nil
@@ -557,6 +584,18 @@ func newTimeout(value interface{}) (*TimeoutValue, error) {
 	}
 }
 
+func newHedge(value interface{}) (*HedgeValue, error) {
+	switch value := value.(type) {
+	case variable:
+		v := string(value)
+		return &HedgeValue{Variable: &v}, nil
+	case int:
+		return &HedgeValue{Int: &value}, nil
+	default:
+		return &HedgeValue{}, fmt.Errorf("got an unknown type : %T", value)
+	}
+}
+
 func newMaxAge(value interface{}) (*MaxAgeValue, error) {
 	switch value := value.(type) {
 	case variable:
@@ -581,22 +620,95 @@ func newSmaxAge(value interface{}) (*SMaxAgeValue, error) {
 	}
 }
 
+func newStaleWhileRevalidate(value interface{}) (*StaleWhileRevalidateValue, error) {
+	switch value := value.(type) {
+	case variable:
+		v := string(value)
+		return &StaleWhileRevalidateValue{Variable: &v}, nil
+	case int:
+		return &StaleWhileRevalidateValue{Int: &value}, nil
+	default:
+		return &StaleWhileRevalidateValue{}, fmt.Errorf("got an unknown type : %T", value)
+	}
+}
+
+func newStaleIfError(value interface{}) (*StaleIfErrorValue, error) {
+	switch value := value.(type) {
+	case variable:
+		v := string(value)
+		return &StaleIfErrorValue{Variable: &v}, nil
+	case int:
+		return &StaleIfErrorValue{Int: &value}, nil
+	default:
+		return &StaleIfErrorValue{}, fmt.Errorf("got an unknown type : %T", value)
+	}
+}
+
+type cacheFlag string
+
+func newCacheFlag(flag interface{}) (cacheFlag, error) {
+	f := flag.(string)
+	return cacheFlag(f), nil
+}
+
 func newDependsOn(target interface{}) (DependsOnValue, error) {
 	d := target.(string)
 	return DependsOnValue(d), nil
 }
 
-type ignoreErrors bool
+type ignoreErrors struct {
+	status []int
+}
+
+type successOn []int
+
+type raw bool
+
+func newFlags(first, others interface{}) ([]interface{}, error) {
+	flags := []interface{}{first}
+
+	if others != nil {
+		for _, f := range flatten(others.([]interface{})) {
+			switch f.(type) {
+			case ignoreErrors, successOn, raw:
+				flags = append(flags, f)
+			}
+		}
+	}
+
+	return flags, nil
+}
+
+func newIgnoreErrors(codes interface{}) (ignoreErrors, error) {
+	if codes == nil {
+		return ignoreErrors{}, nil
+	}
+
+	return ignoreErrors{status: codes.([]int)}, nil
+}
 
-func newFlags(ignoreFlag, others interface{}) (ignoreErrors, error) {
-	i := ignoreFlag.(ignoreErrors)
-	return i, nil
+func newSuccessOn(codes interface{}) (successOn, error) {
+	return codes.([]int), nil
 }
 
-func newIgnoreErrors() (ignoreErrors, error) {
+func newRaw() (raw, error) {
 	return true, nil
 }
 
+func newStatusCodes(first, others interface{}) ([]int, error) {
+	codes := []int{first.(int)}
+
+	if others != nil {
+		for _, c := range flatten(others.([]interface{})) {
+			if c, ok := c.(int); ok {
+				codes = append(codes, c)
+			}
+		}
+	}
+
+	return codes, nil
+}
+
 func newBoolean(boolean []byte) (bool, error) {
 	return strconv.ParseBool(string(boolean))
 }
@@ -142,110 +142,246 @@ var g = &grammar{
 		{
 			name: "USE",
 			pos:  position{line: 21, col: 1, offset: 303},
-			expr: &actionExpr{
+			expr: &choiceExpr{
 				pos: position{line: 21, col: 8, offset: 310},
-				run: (*parser).callonUSE1,
-				expr: &seqExpr{
-					pos: position{line: 21, col: 8, offset: 310},
-					exprs: []interface{}{
-						&litMatcher{
-							pos:        position{line: 21, col: 8, offset: 310},
-							val:        "use",
-							ignoreCase: false,
-							want:       "\"use\"",
-						},
-						&ruleRefExpr{
-							pos:  position{line: 21, col: 14, offset: 316},
-							name: "WS_MAND",
-						},
-						&labeledExpr{
-							pos:   position{line: 21, col: 22, offset: 324},
-							label: "r",
-							expr: &ruleRefExpr{
-								pos:  position{line: 21, col: 25, offset: 327},
-								name: "USE_ACTION",
+				alternatives: []interface{}{
+					&actionExpr{
+						pos: position{line: 21, col: 8, offset: 310},
+						run: (*parser).callonUSE2,
+						expr: &seqExpr{
+							pos: position{line: 21, col: 8, offset: 310},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 21, col: 8, offset: 310},
+									val:        "use",
+									ignoreCase: false,
+									want:       "\"use\"",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 21, col: 14, offset: 316},
+									name: "WS_MAND",
+								},
+								&labeledExpr{
+									pos:   position{line: 21, col: 22, offset: 324},
+									label: "r",
+									expr: &ruleRefExpr{
+										pos:  position{line: 21, col: 25, offset: 327},
+										name: "USE_ACTION",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 21, col: 37, offset: 339},
+									name: "WS",
+								},
+								&labeledExpr{
+									pos:   position{line: 21, col: 40, offset: 342},
+									label: "v",
+									expr: &ruleRefExpr{
+										pos:  position{line: 21, col: 43, offset: 345},
+										name: "USE_VALUE",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 21, col: 54, offset: 356},
+									name: "WS",
+								},
+								&zeroOrMoreExpr{
+									pos: position{line: 21, col: 57, offset: 359},
+									expr: &ruleRefExpr{
+										pos:  position{line: 21, col: 57, offset: 359},
+										name: "LS",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 21, col: 61, offset: 363},
+									name: "WS",
+								},
 							},
 						},
-						&ruleRefExpr{
-							pos:  position{line: 21, col: 37, offset: 339},
-							name: "WS",
-						},
-						&labeledExpr{
-							pos:   position{line: 21, col: 40, offset: 342},
-							label: "v",
-							expr: &ruleRefExpr{
-								pos:  position{line: 21, col: 43, offset: 345},
-								name: "USE_VALUE",
+					},
+					&actionExpr{
+						pos: position{line: 23, col: 5, offset: 393},
+						run: (*parser).callonUSE15,
+						expr: &seqExpr{
+							pos: position{line: 23, col: 5, offset: 393},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 23, col: 5, offset: 393},
+									val:        "use",
+									ignoreCase: false,
+									want:       "\"use\"",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 23, col: 11, offset: 399},
+									name: "WS_MAND",
+								},
+								&labeledExpr{
+									pos:   position{line: 23, col: 19, offset: 407},
+									label: "r",
+									expr: &ruleRefExpr{
+										pos:  position{line: 23, col: 22, offset: 410},
+										name: "USE_STATUS_ACTION",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 23, col: 41, offset: 429},
+									name: "WS_MAND",
+								},
+								&labeledExpr{
+									pos:   position{line: 23, col: 49, offset: 437},
+									label: "v",
+									expr: &ruleRefExpr{
+										pos:  position{line: 23, col: 52, offset: 440},
+										name: "USE_IDENT_VALUE",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 23, col: 69, offset: 457},
+									name: "WS",
+								},
+								&zeroOrMoreExpr{
+									pos: position{line: 23, col: 72, offset: 460},
+									expr: &ruleRefExpr{
+										pos:  position{line: 23, col: 72, offset: 460},
+										name: "LS",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 23, col: 76, offset: 464},
+									name: "WS",
+								},
 							},
 						},
-						&ruleRefExpr{
-							pos:  position{line: 21, col: 54, offset: 356},
-							name: "WS",
-						},
-						&zeroOrMoreExpr{
-							pos: position{line: 21, col: 57, offset: 359},
-							expr: &ruleRefExpr{
-								pos:  position{line: 21, col: 57, offset: 359},
-								name: "LS",
+					},
+					&actionExpr{
+						pos: position{line: 25, col: 5, offset: 494},
+						run: (*parser).callonUSE28,
+						expr: &seqExpr{
+							pos: position{line: 25, col: 5, offset: 494},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 25, col: 5, offset: 494},
+									val:        "use",
+									ignoreCase: false,
+									want:       "\"use\"",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 25, col: 11, offset: 500},
+									name: "WS_MAND",
+								},
+								&labeledExpr{
+									pos:   position{line: 25, col: 19, offset: 508},
+									label: "f",
+									expr: &ruleRefExpr{
+										pos:  position{line: 25, col: 22, offset: 511},
+										name: "USE_FLAG",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 25, col: 32, offset: 521},
+									name: "WS",
+								},
+								&zeroOrMoreExpr{
+									pos: position{line: 25, col: 35, offset: 524},
+									expr: &ruleRefExpr{
+										pos:  position{line: 25, col: 35, offset: 524},
+										name: "LS",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 25, col: 39, offset: 528},
+									name: "WS",
+								},
 							},
 						},
-						&ruleRefExpr{
-							pos:  position{line: 21, col: 61, offset: 363},
-							name: "WS",
-						},
 					},
 				},
 			},
 		},
 		{
 			name: "USE_ACTION",
-			pos:  position{line: 25, col: 1, offset: 392},
+			pos:  position{line: 29, col: 1, offset: 558},
 			expr: &actionExpr{
-				pos: position{line: 25, col: 15, offset: 406},
+				pos: position{line: 29, col: 15, offset: 572},
 				run: (*parser).callonUSE_ACTION1,
 				expr: &choiceExpr{
-					pos: position{line: 25, col: 16, offset: 407},
+					pos: position{line: 29, col: 16, offset: 573},
 					alternatives: []interface{}{
 						&litMatcher{
-							pos:        position{line: 25, col: 16, offset: 407},
+							pos:        position{line: 29, col: 16, offset: 573},
 							val:        "timeout",
 							ignoreCase: false,
 							want:       "\"timeout\"",
 						},
 						&litMatcher{
-							pos:        position{line: 25, col: 28, offset: 419},
+							pos:        position{line: 29, col: 28, offset: 585},
 							val:        "max-age",
 							ignoreCase: false,
 							want:       "\"max-age\"",
 						},
 						&litMatcher{
-							pos:        position{line: 25, col: 40, offset: 431},
+							pos:        position{line: 29, col: 40, offset: 597},
 							val:        "s-max-age",
 							ignoreCase: false,
 							want:       "\"s-max-age\"",
 						},
+						&litMatcher{
+							pos:        position{line: 29, col: 54, offset: 611},
+							val:        "stale-while-revalidate",
+							ignoreCase: false,
+							want:       "\"stale-while-revalidate\"",
+						},
+						&litMatcher{
+							pos:        position{line: 29, col: 81, offset: 638},
+							val:        "stale-if-error",
+							ignoreCase: false,
+							want:       "\"stale-if-error\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "USE_FLAG",
+			pos:  position{line: 33, col: 1, offset: 687},
+			expr: &actionExpr{
+				pos: position{line: 33, col: 13, offset: 699},
+				run: (*parser).callonUSE_FLAG1,
+				expr: &choiceExpr{
+					pos: position{line: 33, col: 14, offset: 700},
+					alternatives: []interface{}{
+						&litMatcher{
+							pos:        position{line: 33, col: 14, offset: 700},
+							val:        "fail-fast",
+							ignoreCase: false,
+							want:       "\"fail-fast\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 28, offset: 714},
+							name: "CACHE_DIRECTIVE",
+						},
 					},
 				},
 			},
 		},
 		{
 			name: "USE_VALUE",
-			pos:  position{line: 29, col: 1, offset: 475},
+			pos:  position{line: 37, col: 1, offset: 762},
 			expr: &actionExpr{
-				pos: position{line: 29, col: 14, offset: 488},
+				pos: position{line: 37, col: 14, offset: 775},
 				run: (*parser).callonUSE_VALUE1,
 				expr: &labeledExpr{
-					pos:   position{line: 29, col: 14, offset: 488},
+					pos:   position{line: 37, col: 14, offset: 775},
 					label: "v",
 					expr: &choiceExpr{
-						pos: position{line: 29, col: 17, offset: 491},
+						pos: position{line: 37, col: 17, offset: 778},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 29, col: 17, offset: 491},
+								pos:  position{line: 37, col: 17, offset: 778},
 								name: "String",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 29, col: 26, offset: 500},
+								pos:  position{line: 37, col: 26, offset: 787},
 								name: "Integer",
 							},
 						},
@@ -253,59 +389,100 @@ var g = &grammar{
 				},
 			},
 		},
+		{
+			name: "USE_STATUS_ACTION",
+			pos:  position{line: 41, col: 1, offset: 824},
+			expr: &actionExpr{
+				pos: position{line: 41, col: 22, offset: 845},
+				run: (*parser).callonUSE_STATUS_ACTION1,
+				expr: &choiceExpr{
+					pos: position{line: 41, col: 23, offset: 846},
+					alternatives: []interface{}{
+						&litMatcher{
+							pos:        position{line: 41, col: 23, offset: 846},
+							val:        "status-from",
+							ignoreCase: false,
+							want:       "\"status-from\"",
+						},
+						&litMatcher{
+							pos:        position{line: 41, col: 39, offset: 862},
+							val:        "status-strategy",
+							ignoreCase: false,
+							want:       "\"status-strategy\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "USE_IDENT_VALUE",
+			pos:  position{line: 45, col: 1, offset: 912},
+			expr: &actionExpr{
+				pos: position{line: 45, col: 20, offset: 931},
+				run: (*parser).callonUSE_IDENT_VALUE1,
+				expr: &labeledExpr{
+					pos:   position{line: 45, col: 20, offset: 931},
+					label: "v",
+					expr: &ruleRefExpr{
+						pos:  position{line: 45, col: 23, offset: 934},
+						name: "IDENT",
+					},
+				},
+			},
+		},
 		{
 			name: "BLOCK",
-			pos:  position{line: 33, col: 1, offset: 537},
+			pos:  position{line: 49, col: 1, offset: 969},
 			expr: &actionExpr{
-				pos: position{line: 33, col: 10, offset: 546},
+				pos: position{line: 49, col: 10, offset: 978},
 				run: (*parser).callonBLOCK1,
 				expr: &seqExpr{
-					pos: position{line: 33, col: 10, offset: 546},
+					pos: position{line: 49, col: 10, offset: 978},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 33, col: 10, offset: 546},
+							pos:   position{line: 49, col: 10, offset: 978},
 							label: "action",
 							expr: &ruleRefExpr{
-								pos:  position{line: 33, col: 18, offset: 554},
+								pos:  position{line: 49, col: 18, offset: 986},
 								name: "ACTION_RULE",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 33, col: 31, offset: 567},
+							pos:   position{line: 49, col: 31, offset: 999},
 							label: "m",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 33, col: 34, offset: 570},
+								pos: position{line: 49, col: 34, offset: 1002},
 								expr: &ruleRefExpr{
-									pos:  position{line: 33, col: 34, offset: 570},
+									pos:  position{line: 49, col: 34, offset: 1002},
 									name: "MODIFIER_RULE",
 								},
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 33, col: 50, offset: 586},
+							pos:   position{line: 49, col: 50, offset: 1018},
 							label: "w",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 33, col: 53, offset: 589},
+								pos: position{line: 49, col: 53, offset: 1021},
 								expr: &ruleRefExpr{
-									pos:  position{line: 33, col: 53, offset: 589},
+									pos:  position{line: 49, col: 53, offset: 1021},
 									name: "WITH_RULE",
 								},
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 33, col: 65, offset: 601},
+							pos:   position{line: 49, col: 65, offset: 1033},
 							label: "f",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 33, col: 67, offset: 603},
+								pos: position{line: 49, col: 67, offset: 1035},
 								expr: &choiceExpr{
-									pos: position{line: 33, col: 68, offset: 604},
+									pos: position{line: 49, col: 68, offset: 1036},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 33, col: 68, offset: 604},
+											pos:  position{line: 49, col: 68, offset: 1036},
 											name: "HIDDEN_RULE",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 33, col: 82, offset: 618},
+											pos:  position{line: 49, col: 82, offset: 1050},
 											name: "ONLY_RULE",
 										},
 									},
@@ -313,18 +490,18 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 33, col: 94, offset: 630},
+							pos:   position{line: 49, col: 94, offset: 1062},
 							label: "fl",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 33, col: 98, offset: 634},
+								pos: position{line: 49, col: 98, offset: 1066},
 								expr: &ruleRefExpr{
-									pos:  position{line: 33, col: 98, offset: 634},
+									pos:  position{line: 49, col: 98, offset: 1066},
 									name: "FLAGS_RULE",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 33, col: 111, offset: 647},
+							pos:  position{line: 49, col: 111, offset: 1079},
 							name: "WS",
 						},
 					},
@@ -333,51 +510,51 @@ var g = &grammar{
 		},
 		{
 			name: "ACTION_RULE",
-			pos:  position{line: 37, col: 1, offset: 693},
+			pos:  position{line: 53, col: 1, offset: 1125},
 			expr: &actionExpr{
-				pos: position{line: 37, col: 16, offset: 708},
+				pos: position{line: 53, col: 16, offset: 1140},
 				run: (*parser).callonACTION_RULE1,
 				expr: &seqExpr{
-					pos: position{line: 37, col: 16, offset: 708},
+					pos: position{line: 53, col: 16, offset: 1140},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 37, col: 16, offset: 708},
+							pos:   position{line: 53, col: 16, offset: 1140},
 							label: "m",
 							expr: &ruleRefExpr{
-								pos:  position{line: 37, col: 19, offset: 711},
+								pos:  position{line: 53, col: 19, offset: 1143},
 								name: "METHOD",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 37, col: 27, offset: 719},
+							pos:  position{line: 53, col: 27, offset: 1151},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 37, col: 35, offset: 727},
+							pos:   position{line: 53, col: 35, offset: 1159},
 							label: "r",
 							expr: &ruleRefExpr{
-								pos:  position{line: 37, col: 38, offset: 730},
+								pos:  position{line: 53, col: 38, offset: 1162},
 								name: "IDENT",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 37, col: 45, offset: 737},
+							pos:   position{line: 53, col: 45, offset: 1169},
 							label: "a",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 37, col: 48, offset: 740},
+								pos: position{line: 53, col: 48, offset: 1172},
 								expr: &ruleRefExpr{
-									pos:  position{line: 37, col: 48, offset: 740},
+									pos:  position{line: 53, col: 48, offset: 1172},
 									name: "ALIAS",
 								},
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 37, col: 56, offset: 748},
+							pos:   position{line: 53, col: 56, offset: 1180},
 							label: "i",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 37, col: 59, offset: 751},
+								pos: position{line: 53, col: 59, offset: 1183},
 								expr: &ruleRefExpr{
-									pos:  position{line: 37, col: 59, offset: 751},
+									pos:  position{line: 53, col: 59, offset: 1183},
 									name: "IN",
 								},
 							},
@@ -388,75 +565,87 @@ var g = &grammar{
 		},
 		{
 			name: "METHOD",
-			pos:  position{line: 41, col: 1, offset: 795},
+			pos:  position{line: 57, col: 1, offset: 1227},
 			expr: &actionExpr{
-				pos: position{line: 41, col: 11, offset: 805},
+				pos: position{line: 57, col: 11, offset: 1237},
 				run: (*parser).callonMETHOD1,
 				expr: &choiceExpr{
-					pos: position{line: 41, col: 12, offset: 806},
+					pos: position{line: 57, col: 12, offset: 1238},
 					alternatives: []interface{}{
 						&litMatcher{
-							pos:        position{line: 41, col: 12, offset: 806},
+							pos:        position{line: 57, col: 12, offset: 1238},
 							val:        "from",
 							ignoreCase: false,
 							want:       "\"from\"",
 						},
 						&litMatcher{
-							pos:        position{line: 41, col: 21, offset: 815},
+							pos:        position{line: 57, col: 21, offset: 1247},
 							val:        "to",
 							ignoreCase: false,
 							want:       "\"to\"",
 						},
 						&litMatcher{
-							pos:        position{line: 41, col: 28, offset: 822},
+							pos:        position{line: 57, col: 28, offset: 1254},
 							val:        "into",
 							ignoreCase: false,
 							want:       "\"into\"",
 						},
 						&litMatcher{
-							pos:        position{line: 41, col: 36, offset: 830},
+							pos:        position{line: 57, col: 36, offset: 1262},
 							val:        "update",
 							ignoreCase: false,
 							want:       "\"update\"",
 						},
 						&litMatcher{
-							pos:        position{line: 41, col: 47, offset: 841},
+							pos:        position{line: 57, col: 47, offset: 1273},
 							val:        "delete",
 							ignoreCase: false,
 							want:       "\"delete\"",
 						},
+						&litMatcher{
+							pos:        position{line: 57, col: 58, offset: 1284},
+							val:        "check",
+							ignoreCase: false,
+							want:       "\"check\"",
+						},
+						&litMatcher{
+							pos:        position{line: 57, col: 68, offset: 1294},
+							val:        "options",
+							ignoreCase: false,
+							want:       "\"options\"",
+						},
 					},
 				},
 			},
 		},
 		{
 			name: "ALIAS",
-			pos:  position{line: 45, col: 1, offset: 882},
+			pos:  position{line: 61, col: 1, offset: 1336},
 			expr: &actionExpr{
-				pos: position{line: 45, col: 10, offset: 891},
+				pos: position{line: 61, col: 10, offset: 1345},
 				run: (*parser).callonALIAS1,
 				expr: &seqExpr{
-					pos: position{line: 45, col: 10, offset: 891},
+					pos: position{line: 61, col: 10, offset: 1345},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 45, col: 10, offset: 891},
+							pos:  position{line: 61, col: 10, offset: 1345},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 45, col: 18, offset: 899},
+							pos:        position{line: 61, col: 18, offset: 1353},
 							val:        "as",
 							ignoreCase: false,
 							want:       "\"as\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 45, col: 23, offset: 904},
+							pos:  position{line: 61, col: 23, offset: 1358},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 45, col: 31, offset: 912},
+							pos:   position{line: 61, col: 31, offset: 1366},
 							label: "a",
 							expr: &ruleRefExpr{
-								pos:  position{line: 45, col: 34, offset: 915},
+								pos:  position{line: 61, col: 34, offset: 1369},
 								name: "IDENT",
 							},
 						},
@@ -466,32 +655,32 @@ var g = &grammar{
 		},
 		{
 			name: "IN",
-			pos:  position{line: 49, col: 1, offset: 942},
+			pos:  position{line: 65, col: 1, offset: 1396},
 			expr: &actionExpr{
-				pos: position{line: 49, col: 7, offset: 948},
+				pos: position{line: 65, col: 7, offset: 1402},
 				run: (*parser).callonIN1,
 				expr: &seqExpr{
-					pos: position{line: 49, col: 7, offset: 948},
+					pos: position{line: 65, col: 7, offset: 1402},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 49, col: 7, offset: 948},
+							pos:  position{line: 65, col: 7, offset: 1402},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 49, col: 15, offset: 956},
+							pos:        position{line: 65, col: 15, offset: 1410},
 							val:        "in",
 							ignoreCase: false,
 							want:       "\"in\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 49, col: 20, offset: 961},
+							pos:  position{line: 65, col: 20, offset: 1415},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 49, col: 28, offset: 969},
+							pos:   position{line: 65, col: 28, offset: 1423},
 							label: "t",
 							expr: &ruleRefExpr{
-								pos:  position{line: 49, col: 31, offset: 972},
+								pos:  position{line: 65, col: 31, offset: 1426},
 								name: "IDENT_WITH_DOT",
 							},
 						},
@@ -501,36 +690,52 @@ var g = &grammar{
 		},
 		{
 			name: "MODIFIER_RULE",
-			pos:  position{line: 53, col: 1, offset: 1010},
+			pos:  position{line: 69, col: 1, offset: 1464},
 			expr: &actionExpr{
-				pos: position{line: 53, col: 18, offset: 1027},
+				pos: position{line: 69, col: 18, offset: 1481},
 				run: (*parser).callonMODIFIER_RULE1,
 				expr: &labeledExpr{
-					pos:   position{line: 53, col: 18, offset: 1027},
+					pos:   position{line: 69, col: 18, offset: 1481},
 					label: "m",
 					expr: &oneOrMoreExpr{
-						pos: position{line: 53, col: 20, offset: 1029},
+						pos: position{line: 69, col: 20, offset: 1483},
 						expr: &choiceExpr{
-							pos: position{line: 53, col: 21, offset: 1030},
+							pos: position{line: 69, col: 21, offset: 1484},
 							alternatives: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 53, col: 21, offset: 1030},
+									pos:  position{line: 69, col: 21, offset: 1484},
 									name: "HEADERS",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 53, col: 31, offset: 1040},
+									pos:  position{line: 69, col: 31, offset: 1494},
 									name: "TIMEOUT",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 53, col: 41, offset: 1050},
+									pos:  position{line: 69, col: 41, offset: 1504},
+									name: "HEDGE",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 69, col: 49, offset: 1512},
 									name: "MAX_AGE",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 53, col: 51, offset: 1060},
+									pos:  position{line: 69, col: 59, offset: 1522},
 									name: "S_MAX_AGE",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 53, col: 63, offset: 1072},
+									pos:  position{line: 69, col: 71, offset: 1534},
+									name: "STALE_WHILE_REVALIDATE",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 69, col: 96, offset: 1559},
+									name: "STALE_IF_ERROR",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 69, col: 113, offset: 1576},
+									name: "CACHE_FLAG",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 69, col: 126, offset: 1589},
 									name: "DEPENDS_ON",
 								},
 							},
@@ -541,45 +746,45 @@ var g = &grammar{
 		},
 		{
 			name: "WITH_RULE",
-			pos:  position{line: 57, col: 1, offset: 1105},
+			pos:  position{line: 73, col: 1, offset: 1622},
 			expr: &actionExpr{
-				pos: position{line: 57, col: 14, offset: 1118},
+				pos: position{line: 73, col: 14, offset: 1635},
 				run: (*parser).callonWITH_RULE1,
 				expr: &seqExpr{
-					pos: position{line: 57, col: 14, offset: 1118},
+					pos: position{line: 73, col: 14, offset: 1635},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 57, col: 14, offset: 1118},
+							pos:  position{line: 73, col: 14, offset: 1635},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 57, col: 22, offset: 1126},
+							pos:        position{line: 73, col: 22, offset: 1643},
 							val:        "with",
 							ignoreCase: false,
 							want:       "\"with\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 57, col: 29, offset: 1133},
+							pos:  position{line: 73, col: 29, offset: 1650},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 57, col: 37, offset: 1141},
+							pos:   position{line: 73, col: 37, offset: 1658},
 							label: "pb",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 57, col: 40, offset: 1144},
+								pos: position{line: 73, col: 40, offset: 1661},
 								expr: &ruleRefExpr{
-									pos:  position{line: 57, col: 40, offset: 1144},
+									pos:  position{line: 73, col: 40, offset: 1661},
 									name: "PARAMETER_BODY",
 								},
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 57, col: 56, offset: 1160},
+							pos:   position{line: 73, col: 56, offset: 1677},
 							label: "kvs",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 57, col: 60, offset: 1164},
+								pos: position{line: 73, col: 60, offset: 1681},
 								expr: &ruleRefExpr{
-									pos:  position{line: 57, col: 60, offset: 1164},
+									pos:  position{line: 73, col: 60, offset: 1681},
 									name: "KEY_VALUE_LIST",
 								},
 							},
@@ -590,51 +795,51 @@ var g = &grammar{
 		},
 		{
 			name: "PARAMETER_BODY",
-			pos:  position{line: 61, col: 1, offset: 1210},
+			pos:  position{line: 77, col: 1, offset: 1727},
 			expr: &actionExpr{
-				pos: position{line: 61, col: 19, offset: 1228},
+				pos: position{line: 77, col: 19, offset: 1745},
 				run: (*parser).callonPARAMETER_BODY1,
 				expr: &seqExpr{
-					pos: position{line: 61, col: 19, offset: 1228},
+					pos: position{line: 77, col: 19, offset: 1745},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 61, col: 19, offset: 1228},
+							pos:        position{line: 77, col: 19, offset: 1745},
 							val:        "$",
 							ignoreCase: false,
 							want:       "\"$\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 61, col: 23, offset: 1232},
+							pos:   position{line: 77, col: 23, offset: 1749},
 							label: "t",
 							expr: &ruleRefExpr{
-								pos:  position{line: 61, col: 26, offset: 1235},
+								pos:  position{line: 77, col: 26, offset: 1752},
 								name: "IDENT",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 61, col: 33, offset: 1242},
+							pos:   position{line: 77, col: 33, offset: 1759},
 							label: "fn",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 61, col: 36, offset: 1245},
+								pos: position{line: 77, col: 36, offset: 1762},
 								expr: &ruleRefExpr{
-									pos:  position{line: 61, col: 37, offset: 1246},
+									pos:  position{line: 77, col: 37, offset: 1763},
 									name: "APPLY_FN",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 61, col: 48, offset: 1257},
+							pos:  position{line: 77, col: 48, offset: 1774},
 							name: "WS",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 61, col: 51, offset: 1260},
+							pos: position{line: 77, col: 51, offset: 1777},
 							expr: &ruleRefExpr{
-								pos:  position{line: 61, col: 51, offset: 1260},
+								pos:  position{line: 77, col: 51, offset: 1777},
 								name: "LS",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 61, col: 55, offset: 1264},
+							pos:  position{line: 77, col: 55, offset: 1781},
 							name: "WS",
 						},
 					},
@@ -643,58 +848,58 @@ var g = &grammar{
 		},
 		{
 			name: "KEY_VALUE_LIST",
-			pos:  position{line: 65, col: 1, offset: 1304},
+			pos:  position{line: 81, col: 1, offset: 1821},
 			expr: &actionExpr{
-				pos: position{line: 65, col: 19, offset: 1322},
+				pos: position{line: 81, col: 19, offset: 1839},
 				run: (*parser).callonKEY_VALUE_LIST1,
 				expr: &seqExpr{
-					pos: position{line: 65, col: 19, offset: 1322},
+					pos: position{line: 81, col: 19, offset: 1839},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 65, col: 19, offset: 1322},
+							pos:   position{line: 81, col: 19, offset: 1839},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 65, col: 25, offset: 1328},
+								pos:  position{line: 81, col: 25, offset: 1845},
 								name: "KEY_VALUE",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 65, col: 35, offset: 1338},
+							pos:   position{line: 81, col: 35, offset: 1855},
 							label: "others",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 65, col: 42, offset: 1345},
+								pos: position{line: 81, col: 42, offset: 1862},
 								expr: &seqExpr{
-									pos: position{line: 65, col: 43, offset: 1346},
+									pos: position{line: 81, col: 43, offset: 1863},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 65, col: 43, offset: 1346},
+											pos:  position{line: 81, col: 43, offset: 1863},
 											name: "WS",
 										},
 										&choiceExpr{
-											pos: position{line: 65, col: 47, offset: 1350},
+											pos: position{line: 81, col: 47, offset: 1867},
 											alternatives: []interface{}{
 												&seqExpr{
-													pos: position{line: 65, col: 47, offset: 1350},
+													pos: position{line: 81, col: 47, offset: 1867},
 													exprs: []interface{}{
 														&ruleRefExpr{
-															pos:  position{line: 65, col: 47, offset: 1350},
+															pos:  position{line: 81, col: 47, offset: 1867},
 															name: "LS",
 														},
 														&zeroOrMoreExpr{
-															pos: position{line: 65, col: 50, offset: 1353},
+															pos: position{line: 81, col: 50, offset: 1870},
 															expr: &seqExpr{
-																pos: position{line: 65, col: 51, offset: 1354},
+																pos: position{line: 81, col: 51, offset: 1871},
 																exprs: []interface{}{
 																	&ruleRefExpr{
-																		pos:  position{line: 65, col: 51, offset: 1354},
+																		pos:  position{line: 81, col: 51, offset: 1871},
 																		name: "WS",
 																	},
 																	&ruleRefExpr{
-																		pos:  position{line: 65, col: 54, offset: 1357},
+																		pos:  position{line: 81, col: 54, offset: 1874},
 																		name: "NL",
 																	},
 																	&ruleRefExpr{
-																		pos:  position{line: 65, col: 57, offset: 1360},
+																		pos:  position{line: 81, col: 57, offset: 1877},
 																		name: "WS",
 																	},
 																},
@@ -703,17 +908,17 @@ var g = &grammar{
 													},
 												},
 												&ruleRefExpr{
-													pos:  position{line: 65, col: 64, offset: 1367},
+													pos:  position{line: 81, col: 64, offset: 1884},
 													name: "LS",
 												},
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 65, col: 68, offset: 1371},
+											pos:  position{line: 81, col: 68, offset: 1888},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 65, col: 71, offset: 1374},
+											pos:  position{line: 81, col: 71, offset: 1891},
 											name: "KEY_VALUE",
 										},
 									},
@@ -726,50 +931,50 @@ var g = &grammar{
 		},
 		{
 			name: "KEY_VALUE",
-			pos:  position{line: 69, col: 1, offset: 1430},
+			pos:  position{line: 85, col: 1, offset: 1947},
 			expr: &actionExpr{
-				pos: position{line: 69, col: 14, offset: 1443},
+				pos: position{line: 85, col: 14, offset: 1960},
 				run: (*parser).callonKEY_VALUE1,
 				expr: &seqExpr{
-					pos: position{line: 69, col: 14, offset: 1443},
+					pos: position{line: 85, col: 14, offset: 1960},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 69, col: 14, offset: 1443},
+							pos:   position{line: 85, col: 14, offset: 1960},
 							label: "k",
 							expr: &ruleRefExpr{
-								pos:  position{line: 69, col: 17, offset: 1446},
+								pos:  position{line: 85, col: 17, offset: 1963},
 								name: "IDENT_WITH_DOT",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 69, col: 33, offset: 1462},
+							pos:  position{line: 85, col: 33, offset: 1979},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 69, col: 36, offset: 1465},
+							pos:        position{line: 85, col: 36, offset: 1982},
 							val:        "=",
 							ignoreCase: false,
 							want:       "\"=\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 69, col: 40, offset: 1469},
+							pos:  position{line: 85, col: 40, offset: 1986},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 69, col: 43, offset: 1472},
+							pos:   position{line: 85, col: 43, offset: 1989},
 							label: "v",
 							expr: &ruleRefExpr{
-								pos:  position{line: 69, col: 46, offset: 1475},
+								pos:  position{line: 85, col: 46, offset: 1992},
 								name: "VALUE",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 69, col: 53, offset: 1482},
+							pos:   position{line: 85, col: 53, offset: 1999},
 							label: "fn",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 69, col: 56, offset: 1485},
+								pos: position{line: 85, col: 56, offset: 2002},
 								expr: &ruleRefExpr{
-									pos:  position{line: 69, col: 57, offset: 1486},
+									pos:  position{line: 85, col: 57, offset: 2003},
 									name: "APPLY_FN",
 								},
 							},
@@ -780,35 +985,35 @@ var g = &grammar{
 		},
 		{
 			name: "APPLY_FN",
-			pos:  position{line: 73, col: 1, offset: 1532},
+			pos:  position{line: 89, col: 1, offset: 2049},
 			expr: &actionExpr{
-				pos: position{line: 73, col: 13, offset: 1544},
+				pos: position{line: 89, col: 13, offset: 2061},
 				run: (*parser).callonAPPLY_FN1,
 				expr: &seqExpr{
-					pos: position{line: 73, col: 13, offset: 1544},
+					pos: position{line: 89, col: 13, offset: 2061},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 73, col: 13, offset: 1544},
+							pos:  position{line: 89, col: 13, offset: 2061},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 73, col: 16, offset: 1547},
+							pos:        position{line: 89, col: 16, offset: 2064},
 							val:        "->",
 							ignoreCase: false,
 							want:       "\"->\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 73, col: 21, offset: 1552},
+							pos: position{line: 89, col: 21, offset: 2069},
 							expr: &ruleRefExpr{
-								pos:  position{line: 73, col: 21, offset: 1552},
+								pos:  position{line: 89, col: 21, offset: 2069},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 73, col: 25, offset: 1556},
+							pos:   position{line: 89, col: 25, offset: 2073},
 							label: "fn",
 							expr: &ruleRefExpr{
-								pos:  position{line: 73, col: 29, offset: 1560},
+								pos:  position{line: 89, col: 29, offset: 2077},
 								name: "FUNCTION",
 							},
 						},
@@ -818,51 +1023,63 @@ var g = &grammar{
 		},
 		{
 			name: "FUNCTION",
-			pos:  position{line: 77, col: 1, offset: 1591},
+			pos:  position{line: 93, col: 1, offset: 2108},
 			expr: &actionExpr{
-				pos: position{line: 77, col: 13, offset: 1603},
+				pos: position{line: 93, col: 13, offset: 2120},
 				run: (*parser).callonFUNCTION1,
 				expr: &choiceExpr{
-					pos: position{line: 77, col: 14, offset: 1604},
+					pos: position{line: 93, col: 14, offset: 2121},
 					alternatives: []interface{}{
 						&litMatcher{
-							pos:        position{line: 77, col: 14, offset: 1604},
+							pos:        position{line: 93, col: 14, offset: 2121},
 							val:        "no-multiplex",
 							ignoreCase: false,
 							want:       "\"no-multiplex\"",
 						},
 						&litMatcher{
-							pos:        position{line: 77, col: 31, offset: 1621},
+							pos:        position{line: 93, col: 31, offset: 2138},
 							val:        "no-explode",
 							ignoreCase: false,
 							want:       "\"no-explode\"",
 						},
 						&litMatcher{
-							pos:        position{line: 77, col: 46, offset: 1636},
+							pos:        position{line: 93, col: 46, offset: 2153},
 							val:        "base64",
 							ignoreCase: false,
 							want:       "\"base64\"",
 						},
 						&litMatcher{
-							pos:        position{line: 77, col: 57, offset: 1647},
+							pos:        position{line: 93, col: 57, offset: 2164},
 							val:        "json",
 							ignoreCase: false,
 							want:       "\"json\"",
 						},
 						&litMatcher{
-							pos:        position{line: 77, col: 65, offset: 1655},
+							pos:        position{line: 93, col: 65, offset: 2172},
 							val:        "as-body",
 							ignoreCase: false,
 							want:       "\"as-body\"",
 						},
 						&litMatcher{
-							pos:        position{line: 77, col: 77, offset: 1667},
+							pos:        position{line: 93, col: 77, offset: 2184},
 							val:        "as-query",
 							ignoreCase: false,
 							want:       "\"as-query\"",
 						},
 						&litMatcher{
-							pos:        position{line: 77, col: 90, offset: 1680},
+							pos:        position{line: 93, col: 90, offset: 2197},
+							val:        "as-form",
+							ignoreCase: false,
+							want:       "\"as-form\"",
+						},
+						&litMatcher{
+							pos:        position{line: 93, col: 102, offset: 2209},
+							val:        "as-multipart",
+							ignoreCase: false,
+							want:       "\"as-multipart\"",
+						},
+						&litMatcher{
+							pos:        position{line: 93, col: 119, offset: 2226},
 							val:        "flatten",
 							ignoreCase: false,
 							want:       "\"flatten\"",
@@ -873,30 +1090,30 @@ var g = &grammar{
 		},
 		{
 			name: "VALUE",
-			pos:  position{line: 81, col: 1, offset: 1722},
+			pos:  position{line: 97, col: 1, offset: 2268},
 			expr: &actionExpr{
-				pos: position{line: 81, col: 10, offset: 1731},
+				pos: position{line: 97, col: 10, offset: 2277},
 				run: (*parser).callonVALUE1,
 				expr: &labeledExpr{
-					pos:   position{line: 81, col: 10, offset: 1731},
+					pos:   position{line: 97, col: 10, offset: 2277},
 					label: "v",
 					expr: &choiceExpr{
-						pos: position{line: 81, col: 13, offset: 1734},
+						pos: position{line: 97, col: 13, offset: 2280},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 81, col: 13, offset: 1734},
+								pos:  position{line: 97, col: 13, offset: 2280},
 								name: "LIST",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 81, col: 20, offset: 1741},
+								pos:  position{line: 97, col: 20, offset: 2287},
 								name: "OBJECT",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 81, col: 29, offset: 1750},
+								pos:  position{line: 97, col: 29, offset: 2296},
 								name: "VARIABLE",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 81, col: 40, offset: 1761},
+								pos:  position{line: 97, col: 40, offset: 2307},
 								name: "PRIMITIVE",
 							},
 						},
@@ -906,22 +1123,22 @@ var g = &grammar{
 		},
 		{
 			name: "LIST",
-			pos:  position{line: 85, col: 1, offset: 1797},
+			pos:  position{line: 101, col: 1, offset: 2343},
 			expr: &actionExpr{
-				pos: position{line: 85, col: 9, offset: 1805},
+				pos: position{line: 101, col: 9, offset: 2351},
 				run: (*parser).callonLIST1,
 				expr: &labeledExpr{
-					pos:   position{line: 85, col: 9, offset: 1805},
+					pos:   position{line: 101, col: 9, offset: 2351},
 					label: "l",
 					expr: &choiceExpr{
-						pos: position{line: 85, col: 12, offset: 1808},
+						pos: position{line: 101, col: 12, offset: 2354},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 85, col: 12, offset: 1808},
+								pos:  position{line: 101, col: 12, offset: 2354},
 								name: "EMPTY_LIST",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 85, col: 25, offset: 1821},
+								pos:  position{line: 101, col: 25, offset: 2367},
 								name: "POPULATED_LIST",
 							},
 						},
@@ -931,25 +1148,25 @@ var g = &grammar{
 		},
 		{
 			name: "EMPTY_LIST",
-			pos:  position{line: 89, col: 1, offset: 1857},
+			pos:  position{line: 105, col: 1, offset: 2403},
 			expr: &actionExpr{
-				pos: position{line: 89, col: 15, offset: 1871},
+				pos: position{line: 105, col: 15, offset: 2417},
 				run: (*parser).callonEMPTY_LIST1,
 				expr: &seqExpr{
-					pos: position{line: 89, col: 15, offset: 1871},
+					pos: position{line: 105, col: 15, offset: 2417},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 89, col: 15, offset: 1871},
+							pos:        position{line: 105, col: 15, offset: 2417},
 							val:        "[",
 							ignoreCase: false,
 							want:       "\"[\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 89, col: 19, offset: 1875},
+							pos:  position{line: 105, col: 19, offset: 2421},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 89, col: 22, offset: 1878},
+							pos:        position{line: 105, col: 22, offset: 2424},
 							val:        "]",
 							ignoreCase: false,
 							want:       "\"]\"",
@@ -960,56 +1177,56 @@ var g = &grammar{
 		},
 		{
 			name: "POPULATED_LIST",
-			pos:  position{line: 93, col: 1, offset: 1910},
+			pos:  position{line: 109, col: 1, offset: 2456},
 			expr: &actionExpr{
-				pos: position{line: 93, col: 19, offset: 1928},
+				pos: position{line: 109, col: 19, offset: 2474},
 				run: (*parser).callonPOPULATED_LIST1,
 				expr: &seqExpr{
-					pos: position{line: 93, col: 19, offset: 1928},
+					pos: position{line: 109, col: 19, offset: 2474},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 93, col: 19, offset: 1928},
+							pos:        position{line: 109, col: 19, offset: 2474},
 							val:        "[",
 							ignoreCase: false,
 							want:       "\"[\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 93, col: 23, offset: 1932},
+							pos:  position{line: 109, col: 23, offset: 2478},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 93, col: 26, offset: 1935},
+							pos:   position{line: 109, col: 26, offset: 2481},
 							label: "i",
 							expr: &ruleRefExpr{
-								pos:  position{line: 93, col: 28, offset: 1937},
+								pos:  position{line: 109, col: 28, offset: 2483},
 								name: "VALUE",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 93, col: 34, offset: 1943},
+							pos:   position{line: 109, col: 34, offset: 2489},
 							label: "ii",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 93, col: 37, offset: 1946},
+								pos: position{line: 109, col: 37, offset: 2492},
 								expr: &seqExpr{
-									pos: position{line: 93, col: 38, offset: 1947},
+									pos: position{line: 109, col: 38, offset: 2493},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 93, col: 38, offset: 1947},
+											pos:  position{line: 109, col: 38, offset: 2493},
 											name: "WS",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 93, col: 41, offset: 1950},
+											pos: position{line: 109, col: 41, offset: 2496},
 											expr: &ruleRefExpr{
-												pos:  position{line: 93, col: 41, offset: 1950},
+												pos:  position{line: 109, col: 41, offset: 2496},
 												name: "LS",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 93, col: 45, offset: 1954},
+											pos:  position{line: 109, col: 45, offset: 2500},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 93, col: 48, offset: 1957},
+											pos:  position{line: 109, col: 48, offset: 2503},
 											name: "VALUE",
 										},
 									},
@@ -1017,11 +1234,11 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 93, col: 56, offset: 1965},
+							pos:  position{line: 109, col: 56, offset: 2511},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 93, col: 59, offset: 1968},
+							pos:        position{line: 109, col: 59, offset: 2514},
 							val:        "]",
 							ignoreCase: false,
 							want:       "\"]\"",
@@ -1032,22 +1249,22 @@ var g = &grammar{
 		},
 		{
 			name: "OBJECT",
-			pos:  position{line: 97, col: 1, offset: 2000},
+			pos:  position{line: 113, col: 1, offset: 2546},
 			expr: &actionExpr{
-				pos: position{line: 97, col: 11, offset: 2010},
+				pos: position{line: 113, col: 11, offset: 2556},
 				run: (*parser).callonOBJECT1,
 				expr: &labeledExpr{
-					pos:   position{line: 97, col: 11, offset: 2010},
+					pos:   position{line: 113, col: 11, offset: 2556},
 					label: "o",
 					expr: &choiceExpr{
-						pos: position{line: 97, col: 14, offset: 2013},
+						pos: position{line: 113, col: 14, offset: 2559},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 97, col: 14, offset: 2013},
+								pos:  position{line: 113, col: 14, offset: 2559},
 								name: "EMPTY_OBJ",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 97, col: 26, offset: 2025},
+								pos:  position{line: 113, col: 26, offset: 2571},
 								name: "POPULATED_OBJ",
 							},
 						},
@@ -1057,36 +1274,36 @@ var g = &grammar{
 		},
 		{
 			name: "EMPTY_OBJ",
-			pos:  position{line: 101, col: 1, offset: 2060},
+			pos:  position{line: 117, col: 1, offset: 2606},
 			expr: &actionExpr{
-				pos: position{line: 101, col: 14, offset: 2073},
+				pos: position{line: 117, col: 14, offset: 2619},
 				run: (*parser).callonEMPTY_OBJ1,
 				expr: &seqExpr{
-					pos: position{line: 101, col: 14, offset: 2073},
+					pos: position{line: 117, col: 14, offset: 2619},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 101, col: 14, offset: 2073},
+							pos:        position{line: 117, col: 14, offset: 2619},
 							val:        "{",
 							ignoreCase: false,
 							want:       "\"{\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 101, col: 18, offset: 2077},
+							pos:  position{line: 117, col: 18, offset: 2623},
 							name: "WS",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 101, col: 21, offset: 2080},
+							pos: position{line: 117, col: 21, offset: 2626},
 							expr: &ruleRefExpr{
-								pos:  position{line: 101, col: 21, offset: 2080},
+								pos:  position{line: 117, col: 21, offset: 2626},
 								name: "NL",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 101, col: 25, offset: 2084},
+							pos:  position{line: 117, col: 25, offset: 2630},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 101, col: 28, offset: 2087},
+							pos:        position{line: 117, col: 28, offset: 2633},
 							val:        "}",
 							ignoreCase: false,
 							want:       "\"}\"",
@@ -1097,77 +1314,77 @@ var g = &grammar{
 		},
 		{
 			name: "POPULATED_OBJ",
-			pos:  position{line: 105, col: 1, offset: 2121},
+			pos:  position{line: 121, col: 1, offset: 2667},
 			expr: &actionExpr{
-				pos: position{line: 105, col: 18, offset: 2138},
+				pos: position{line: 121, col: 18, offset: 2684},
 				run: (*parser).callonPOPULATED_OBJ1,
 				expr: &seqExpr{
-					pos: position{line: 105, col: 18, offset: 2138},
+					pos: position{line: 121, col: 18, offset: 2684},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 105, col: 18, offset: 2138},
+							pos:        position{line: 121, col: 18, offset: 2684},
 							val:        "{",
 							ignoreCase: false,
 							want:       "\"{\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 105, col: 22, offset: 2142},
+							pos:  position{line: 121, col: 22, offset: 2688},
 							name: "WS",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 105, col: 25, offset: 2145},
+							pos: position{line: 121, col: 25, offset: 2691},
 							expr: &ruleRefExpr{
-								pos:  position{line: 105, col: 25, offset: 2145},
+								pos:  position{line: 121, col: 25, offset: 2691},
 								name: "NL",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 105, col: 29, offset: 2149},
+							pos:  position{line: 121, col: 29, offset: 2695},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 105, col: 32, offset: 2152},
+							pos:   position{line: 121, col: 32, offset: 2698},
 							label: "oe",
 							expr: &ruleRefExpr{
-								pos:  position{line: 105, col: 36, offset: 2156},
+								pos:  position{line: 121, col: 36, offset: 2702},
 								name: "OBJ_ENTRY",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 105, col: 47, offset: 2167},
+							pos:   position{line: 121, col: 47, offset: 2713},
 							label: "oes",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 105, col: 51, offset: 2171},
+								pos: position{line: 121, col: 51, offset: 2717},
 								expr: &seqExpr{
-									pos: position{line: 105, col: 52, offset: 2172},
+									pos: position{line: 121, col: 52, offset: 2718},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 105, col: 52, offset: 2172},
+											pos:  position{line: 121, col: 52, offset: 2718},
 											name: "WS",
 										},
 										&litMatcher{
-											pos:        position{line: 105, col: 55, offset: 2175},
+											pos:        position{line: 121, col: 55, offset: 2721},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 105, col: 59, offset: 2179},
+											pos:  position{line: 121, col: 59, offset: 2725},
 											name: "WS",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 105, col: 62, offset: 2182},
+											pos: position{line: 121, col: 62, offset: 2728},
 											expr: &ruleRefExpr{
-												pos:  position{line: 105, col: 62, offset: 2182},
+												pos:  position{line: 121, col: 62, offset: 2728},
 												name: "NL",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 105, col: 66, offset: 2186},
+											pos:  position{line: 121, col: 66, offset: 2732},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 105, col: 69, offset: 2189},
+											pos:  position{line: 121, col: 69, offset: 2735},
 											name: "OBJ_ENTRY",
 										},
 									},
@@ -1175,22 +1392,22 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 105, col: 81, offset: 2201},
+							pos:  position{line: 121, col: 81, offset: 2747},
 							name: "WS",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 105, col: 84, offset: 2204},
+							pos: position{line: 121, col: 84, offset: 2750},
 							expr: &ruleRefExpr{
-								pos:  position{line: 105, col: 84, offset: 2204},
+								pos:  position{line: 121, col: 84, offset: 2750},
 								name: "NL",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 105, col: 88, offset: 2208},
+							pos:  position{line: 121, col: 88, offset: 2754},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 105, col: 91, offset: 2211},
+							pos:        position{line: 121, col: 91, offset: 2757},
 							val:        "}",
 							ignoreCase: false,
 							want:       "\"}\"",
@@ -1201,49 +1418,49 @@ var g = &grammar{
 		},
 		{
 			name: "OBJ_ENTRY",
-			pos:  position{line: 109, col: 1, offset: 2256},
+			pos:  position{line: 125, col: 1, offset: 2802},
 			expr: &actionExpr{
-				pos: position{line: 109, col: 14, offset: 2269},
+				pos: position{line: 125, col: 14, offset: 2815},
 				run: (*parser).callonOBJ_ENTRY1,
 				expr: &seqExpr{
-					pos: position{line: 109, col: 14, offset: 2269},
+					pos: position{line: 125, col: 14, offset: 2815},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 109, col: 14, offset: 2269},
+							pos:   position{line: 125, col: 14, offset: 2815},
 							label: "k",
 							expr: &choiceExpr{
-								pos: position{line: 109, col: 17, offset: 2272},
+								pos: position{line: 125, col: 17, offset: 2818},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 109, col: 17, offset: 2272},
+										pos:  position{line: 125, col: 17, offset: 2818},
 										name: "String",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 109, col: 26, offset: 2281},
+										pos:  position{line: 125, col: 26, offset: 2827},
 										name: "IDENT_WITHOUT_COLLON",
 									},
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 109, col: 48, offset: 2303},
+							pos:  position{line: 125, col: 48, offset: 2849},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 109, col: 51, offset: 2306},
+							pos:        position{line: 125, col: 51, offset: 2852},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 109, col: 55, offset: 2310},
+							pos:  position{line: 125, col: 55, offset: 2856},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 109, col: 58, offset: 2313},
+							pos:   position{line: 125, col: 58, offset: 2859},
 							label: "v",
 							expr: &ruleRefExpr{
-								pos:  position{line: 109, col: 61, offset: 2316},
+								pos:  position{line: 125, col: 61, offset: 2862},
 								name: "VALUE",
 							},
 						},
@@ -1253,38 +1470,38 @@ var g = &grammar{
 		},
 		{
 			name: "PRIMITIVE",
-			pos:  position{line: 113, col: 1, offset: 2357},
+			pos:  position{line: 129, col: 1, offset: 2903},
 			expr: &actionExpr{
-				pos: position{line: 113, col: 14, offset: 2370},
+				pos: position{line: 129, col: 14, offset: 2916},
 				run: (*parser).callonPRIMITIVE1,
 				expr: &labeledExpr{
-					pos:   position{line: 113, col: 14, offset: 2370},
+					pos:   position{line: 129, col: 14, offset: 2916},
 					label: "p",
 					expr: &choiceExpr{
-						pos: position{line: 113, col: 17, offset: 2373},
+						pos: position{line: 129, col: 17, offset: 2919},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 113, col: 17, offset: 2373},
+								pos:  position{line: 129, col: 17, offset: 2919},
 								name: "Null",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 113, col: 24, offset: 2380},
+								pos:  position{line: 129, col: 24, offset: 2926},
 								name: "Boolean",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 113, col: 34, offset: 2390},
+								pos:  position{line: 129, col: 34, offset: 2936},
 								name: "String",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 113, col: 43, offset: 2399},
+								pos:  position{line: 129, col: 43, offset: 2945},
 								name: "Float",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 113, col: 51, offset: 2407},
+								pos:  position{line: 129, col: 51, offset: 2953},
 								name: "Integer",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 113, col: 61, offset: 2417},
+								pos:  position{line: 129, col: 61, offset: 2963},
 								name: "CHAIN",
 							},
 						},
@@ -1294,65 +1511,65 @@ var g = &grammar{
 		},
 		{
 			name: "ONLY_RULE",
-			pos:  position{line: 119, col: 1, offset: 2455},
+			pos:  position{line: 135, col: 1, offset: 3001},
 			expr: &actionExpr{
-				pos: position{line: 119, col: 14, offset: 2468},
+				pos: position{line: 135, col: 14, offset: 3014},
 				run: (*parser).callonONLY_RULE1,
 				expr: &seqExpr{
-					pos: position{line: 119, col: 14, offset: 2468},
+					pos: position{line: 135, col: 14, offset: 3014},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 119, col: 14, offset: 2468},
+							pos:  position{line: 135, col: 14, offset: 3014},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 119, col: 22, offset: 2476},
+							pos:        position{line: 135, col: 22, offset: 3022},
 							val:        "only",
 							ignoreCase: false,
 							want:       "\"only\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 119, col: 29, offset: 2483},
+							pos:  position{line: 135, col: 29, offset: 3029},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 119, col: 37, offset: 2491},
+							pos:   position{line: 135, col: 37, offset: 3037},
 							label: "f",
 							expr: &ruleRefExpr{
-								pos:  position{line: 119, col: 40, offset: 2494},
+								pos:  position{line: 135, col: 40, offset: 3040},
 								name: "FILTER",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 119, col: 48, offset: 2502},
+							pos:   position{line: 135, col: 48, offset: 3048},
 							label: "fs",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 119, col: 51, offset: 2505},
+								pos: position{line: 135, col: 51, offset: 3051},
 								expr: &seqExpr{
-									pos: position{line: 119, col: 52, offset: 2506},
+									pos: position{line: 135, col: 52, offset: 3052},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 119, col: 52, offset: 2506},
+											pos:  position{line: 135, col: 52, offset: 3052},
 											name: "WS",
 										},
 										&notExpr{
-											pos: position{line: 119, col: 55, offset: 2509},
+											pos: position{line: 135, col: 55, offset: 3055},
 											expr: &choiceExpr{
-												pos: position{line: 119, col: 57, offset: 2511},
+												pos: position{line: 135, col: 57, offset: 3057},
 												alternatives: []interface{}{
 													&ruleRefExpr{
-														pos:  position{line: 119, col: 57, offset: 2511},
+														pos:  position{line: 135, col: 57, offset: 3057},
 														name: "FLAGS_RULE",
 													},
 													&seqExpr{
-														pos: position{line: 119, col: 70, offset: 2524},
+														pos: position{line: 135, col: 70, offset: 3070},
 														exprs: []interface{}{
 															&ruleRefExpr{
-																pos:  position{line: 119, col: 70, offset: 2524},
+																pos:  position{line: 135, col: 70, offset: 3070},
 																name: "BS",
 															},
 															&ruleRefExpr{
-																pos:  position{line: 119, col: 73, offset: 2527},
+																pos:  position{line: 135, col: 73, offset: 3073},
 																name: "BLOCK",
 															},
 														},
@@ -1361,30 +1578,30 @@ var g = &grammar{
 											},
 										},
 										&choiceExpr{
-											pos: position{line: 119, col: 81, offset: 2535},
+											pos: position{line: 135, col: 81, offset: 3081},
 											alternatives: []interface{}{
 												&seqExpr{
-													pos: position{line: 119, col: 81, offset: 2535},
+													pos: position{line: 135, col: 81, offset: 3081},
 													exprs: []interface{}{
 														&ruleRefExpr{
-															pos:  position{line: 119, col: 81, offset: 2535},
+															pos:  position{line: 135, col: 81, offset: 3081},
 															name: "LS",
 														},
 														&zeroOrMoreExpr{
-															pos: position{line: 119, col: 84, offset: 2538},
+															pos: position{line: 135, col: 84, offset: 3084},
 															expr: &seqExpr{
-																pos: position{line: 119, col: 85, offset: 2539},
+																pos: position{line: 135, col: 85, offset: 3085},
 																exprs: []interface{}{
 																	&ruleRefExpr{
-																		pos:  position{line: 119, col: 85, offset: 2539},
+																		pos:  position{line: 135, col: 85, offset: 3085},
 																		name: "WS",
 																	},
 																	&ruleRefExpr{
-																		pos:  position{line: 119, col: 88, offset: 2542},
+																		pos:  position{line: 135, col: 88, offset: 3088},
 																		name: "NL",
 																	},
 																	&ruleRefExpr{
-																		pos:  position{line: 119, col: 91, offset: 2545},
+																		pos:  position{line: 135, col: 91, offset: 3091},
 																		name: "WS",
 																	},
 																},
@@ -1393,17 +1610,17 @@ var g = &grammar{
 													},
 												},
 												&ruleRefExpr{
-													pos:  position{line: 119, col: 98, offset: 2552},
+													pos:  position{line: 135, col: 98, offset: 3098},
 													name: "LS",
 												},
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 119, col: 102, offset: 2556},
+											pos:  position{line: 135, col: 102, offset: 3102},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 119, col: 105, offset: 2559},
+											pos:  position{line: 135, col: 105, offset: 3105},
 											name: "FILTER",
 										},
 									},
@@ -1416,28 +1633,28 @@ var g = &grammar{
 		},
 		{
 			name: "FILTER",
-			pos:  position{line: 123, col: 1, offset: 2596},
+			pos:  position{line: 139, col: 1, offset: 3142},
 			expr: &actionExpr{
-				pos: position{line: 123, col: 11, offset: 2606},
+				pos: position{line: 139, col: 11, offset: 3152},
 				run: (*parser).callonFILTER1,
 				expr: &seqExpr{
-					pos: position{line: 123, col: 11, offset: 2606},
+					pos: position{line: 139, col: 11, offset: 3152},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 123, col: 11, offset: 2606},
+							pos:   position{line: 139, col: 11, offset: 3152},
 							label: "f",
 							expr: &ruleRefExpr{
-								pos:  position{line: 123, col: 14, offset: 2609},
+								pos:  position{line: 139, col: 14, offset: 3155},
 								name: "FILTER_VALUE",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 123, col: 28, offset: 2623},
+							pos:   position{line: 139, col: 28, offset: 3169},
 							label: "fns",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 123, col: 32, offset: 2627},
+								pos: position{line: 139, col: 32, offset: 3173},
 								expr: &ruleRefExpr{
-									pos:  position{line: 123, col: 33, offset: 2628},
+									pos:  position{line: 139, col: 33, offset: 3174},
 									name: "APPLY_FILTER_FN",
 								},
 							},
@@ -1448,22 +1665,22 @@ var g = &grammar{
 		},
 		{
 			name: "FILTER_VALUE",
-			pos:  position{line: 127, col: 1, offset: 2677},
+			pos:  position{line: 143, col: 1, offset: 3223},
 			expr: &actionExpr{
-				pos: position{line: 127, col: 17, offset: 2693},
+				pos: position{line: 143, col: 17, offset: 3239},
 				run: (*parser).callonFILTER_VALUE1,
 				expr: &labeledExpr{
-					pos:   position{line: 127, col: 17, offset: 2693},
+					pos:   position{line: 143, col: 17, offset: 3239},
 					label: "fv",
 					expr: &choiceExpr{
-						pos: position{line: 127, col: 21, offset: 2697},
+						pos: position{line: 143, col: 21, offset: 3243},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 127, col: 21, offset: 2697},
+								pos:  position{line: 143, col: 21, offset: 3243},
 								name: "IDENT_WITH_DOT",
 							},
 							&litMatcher{
-								pos:        position{line: 127, col: 38, offset: 2714},
+								pos:        position{line: 143, col: 38, offset: 3260},
 								val:        "*",
 								ignoreCase: false,
 								want:       "\"*\"",
@@ -1475,35 +1692,35 @@ var g = &grammar{
 		},
 		{
 			name: "APPLY_FILTER_FN",
-			pos:  position{line: 131, col: 1, offset: 2751},
+			pos:  position{line: 147, col: 1, offset: 3297},
 			expr: &actionExpr{
-				pos: position{line: 131, col: 20, offset: 2770},
+				pos: position{line: 147, col: 20, offset: 3316},
 				run: (*parser).callonAPPLY_FILTER_FN1,
 				expr: &seqExpr{
-					pos: position{line: 131, col: 20, offset: 2770},
+					pos: position{line: 147, col: 20, offset: 3316},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 131, col: 20, offset: 2770},
+							pos:  position{line: 147, col: 20, offset: 3316},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 131, col: 23, offset: 2773},
+							pos:        position{line: 147, col: 23, offset: 3319},
 							val:        "->",
 							ignoreCase: false,
 							want:       "\"->\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 131, col: 28, offset: 2778},
+							pos: position{line: 147, col: 28, offset: 3324},
 							expr: &ruleRefExpr{
-								pos:  position{line: 131, col: 28, offset: 2778},
+								pos:  position{line: 147, col: 28, offset: 3324},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 131, col: 32, offset: 2782},
+							pos:   position{line: 147, col: 32, offset: 3328},
 							label: "fn",
 							expr: &ruleRefExpr{
-								pos:  position{line: 131, col: 36, offset: 2786},
+								pos:  position{line: 147, col: 36, offset: 3332},
 								name: "FILTER_FUNCTION",
 							},
 						},
@@ -1513,22 +1730,22 @@ var g = &grammar{
 		},
 		{
 			name: "FILTER_FUNCTION",
-			pos:  position{line: 135, col: 1, offset: 2824},
+			pos:  position{line: 151, col: 1, offset: 3370},
 			expr: &actionExpr{
-				pos: position{line: 135, col: 20, offset: 2843},
+				pos: position{line: 151, col: 20, offset: 3389},
 				run: (*parser).callonFILTER_FUNCTION1,
 				expr: &labeledExpr{
-					pos:   position{line: 135, col: 20, offset: 2843},
+					pos:   position{line: 151, col: 20, offset: 3389},
 					label: "f",
 					expr: &choiceExpr{
-						pos: position{line: 135, col: 23, offset: 2846},
+						pos: position{line: 151, col: 23, offset: 3392},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 135, col: 23, offset: 2846},
+								pos:  position{line: 151, col: 23, offset: 3392},
 								name: "MATCHES",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 135, col: 33, offset: 2856},
+								pos:  position{line: 151, col: 33, offset: 3402},
 								name: "FILTER_BY_REGEX",
 							},
 						},
@@ -1538,44 +1755,44 @@ var g = &grammar{
 		},
 		{
 			name: "MATCHES",
-			pos:  position{line: 139, col: 1, offset: 2893},
+			pos:  position{line: 155, col: 1, offset: 3439},
 			expr: &actionExpr{
-				pos: position{line: 139, col: 12, offset: 2904},
+				pos: position{line: 155, col: 12, offset: 3450},
 				run: (*parser).callonMATCHES1,
 				expr: &seqExpr{
-					pos: position{line: 139, col: 12, offset: 2904},
+					pos: position{line: 155, col: 12, offset: 3450},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 139, col: 12, offset: 2904},
+							pos:        position{line: 155, col: 12, offset: 3450},
 							val:        "matches",
 							ignoreCase: false,
 							want:       "\"matches\"",
 						},
 						&litMatcher{
-							pos:        position{line: 139, col: 22, offset: 2914},
+							pos:        position{line: 155, col: 22, offset: 3460},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 139, col: 26, offset: 2918},
+							pos:   position{line: 155, col: 26, offset: 3464},
 							label: "arg",
 							expr: &choiceExpr{
-								pos: position{line: 139, col: 31, offset: 2923},
+								pos: position{line: 155, col: 31, offset: 3469},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 139, col: 31, offset: 2923},
+										pos:  position{line: 155, col: 31, offset: 3469},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 139, col: 42, offset: 2934},
+										pos:  position{line: 155, col: 42, offset: 3480},
 										name: "String",
 									},
 								},
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 139, col: 50, offset: 2942},
+							pos:        position{line: 155, col: 50, offset: 3488},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -1586,95 +1803,95 @@ var g = &grammar{
 		},
 		{
 			name: "FILTER_BY_REGEX",
-			pos:  position{line: 143, col: 1, offset: 2979},
+			pos:  position{line: 159, col: 1, offset: 3525},
 			expr: &actionExpr{
-				pos: position{line: 143, col: 20, offset: 2998},
+				pos: position{line: 159, col: 20, offset: 3544},
 				run: (*parser).callonFILTER_BY_REGEX1,
 				expr: &seqExpr{
-					pos: position{line: 143, col: 20, offset: 2998},
+					pos: position{line: 159, col: 20, offset: 3544},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 143, col: 20, offset: 2998},
+							pos:        position{line: 159, col: 20, offset: 3544},
 							val:        "filterByRegex",
 							ignoreCase: false,
 							want:       "\"filterByRegex\"",
 						},
 						&litMatcher{
-							pos:        position{line: 143, col: 36, offset: 3014},
+							pos:        position{line: 159, col: 36, offset: 3560},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 143, col: 40, offset: 3018},
+							pos: position{line: 159, col: 40, offset: 3564},
 							expr: &ruleRefExpr{
-								pos:  position{line: 143, col: 40, offset: 3018},
+								pos:  position{line: 159, col: 40, offset: 3564},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 143, col: 44, offset: 3022},
+							pos:   position{line: 159, col: 44, offset: 3568},
 							label: "path",
 							expr: &choiceExpr{
-								pos: position{line: 143, col: 50, offset: 3028},
+								pos: position{line: 159, col: 50, offset: 3574},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 143, col: 50, offset: 3028},
+										pos:  position{line: 159, col: 50, offset: 3574},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 143, col: 61, offset: 3039},
+										pos:  position{line: 159, col: 61, offset: 3585},
 										name: "String",
 									},
 								},
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 143, col: 69, offset: 3047},
+							pos: position{line: 159, col: 69, offset: 3593},
 							expr: &ruleRefExpr{
-								pos:  position{line: 143, col: 69, offset: 3047},
+								pos:  position{line: 159, col: 69, offset: 3593},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 143, col: 73, offset: 3051},
+							pos:        position{line: 159, col: 73, offset: 3597},
 							val:        ",",
 							ignoreCase: false,
 							want:       "\",\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 143, col: 77, offset: 3055},
+							pos: position{line: 159, col: 77, offset: 3601},
 							expr: &ruleRefExpr{
-								pos:  position{line: 143, col: 77, offset: 3055},
+								pos:  position{line: 159, col: 77, offset: 3601},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 143, col: 81, offset: 3059},
+							pos:   position{line: 159, col: 81, offset: 3605},
 							label: "regex",
 							expr: &choiceExpr{
-								pos: position{line: 143, col: 88, offset: 3066},
+								pos: position{line: 159, col: 88, offset: 3612},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 143, col: 88, offset: 3066},
+										pos:  position{line: 159, col: 88, offset: 3612},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 143, col: 99, offset: 3077},
+										pos:  position{line: 159, col: 99, offset: 3623},
 										name: "String",
 									},
 								},
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 143, col: 107, offset: 3085},
+							pos: position{line: 159, col: 107, offset: 3631},
 							expr: &ruleRefExpr{
-								pos:  position{line: 143, col: 107, offset: 3085},
+								pos:  position{line: 159, col: 107, offset: 3631},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 143, col: 112, offset: 3090},
+							pos:        position{line: 159, col: 112, offset: 3636},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -1685,57 +1902,57 @@ var g = &grammar{
 		},
 		{
 			name: "HEADERS",
-			pos:  position{line: 147, col: 1, offset: 3137},
+			pos:  position{line: 163, col: 1, offset: 3683},
 			expr: &actionExpr{
-				pos: position{line: 147, col: 12, offset: 3148},
+				pos: position{line: 163, col: 12, offset: 3694},
 				run: (*parser).callonHEADERS1,
 				expr: &seqExpr{
-					pos: position{line: 147, col: 12, offset: 3148},
+					pos: position{line: 163, col: 12, offset: 3694},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 147, col: 12, offset: 3148},
+							pos:  position{line: 163, col: 12, offset: 3694},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 147, col: 20, offset: 3156},
+							pos:        position{line: 163, col: 20, offset: 3702},
 							val:        "headers",
 							ignoreCase: false,
 							want:       "\"headers\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 147, col: 30, offset: 3166},
+							pos:  position{line: 163, col: 30, offset: 3712},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 147, col: 38, offset: 3174},
+							pos:   position{line: 163, col: 38, offset: 3720},
 							label: "h",
 							expr: &ruleRefExpr{
-								pos:  position{line: 147, col: 41, offset: 3177},
+								pos:  position{line: 163, col: 41, offset: 3723},
 								name: "HEADER",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 147, col: 49, offset: 3185},
+							pos:   position{line: 163, col: 49, offset: 3731},
 							label: "hs",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 147, col: 52, offset: 3188},
+								pos: position{line: 163, col: 52, offset: 3734},
 								expr: &seqExpr{
-									pos: position{line: 147, col: 53, offset: 3189},
+									pos: position{line: 163, col: 53, offset: 3735},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 147, col: 53, offset: 3189},
+											pos:  position{line: 163, col: 53, offset: 3735},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 147, col: 56, offset: 3192},
+											pos:  position{line: 163, col: 56, offset: 3738},
 											name: "LS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 147, col: 59, offset: 3195},
+											pos:  position{line: 163, col: 59, offset: 3741},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 147, col: 62, offset: 3198},
+											pos:  position{line: 163, col: 62, offset: 3744},
 											name: "HEADER",
 										},
 									},
@@ -1748,51 +1965,51 @@ var g = &grammar{
 		},
 		{
 			name: "HEADER",
-			pos:  position{line: 151, col: 1, offset: 3238},
+			pos:  position{line: 167, col: 1, offset: 3784},
 			expr: &actionExpr{
-				pos: position{line: 151, col: 11, offset: 3248},
+				pos: position{line: 167, col: 11, offset: 3794},
 				run: (*parser).callonHEADER1,
 				expr: &seqExpr{
-					pos: position{line: 151, col: 11, offset: 3248},
+					pos: position{line: 167, col: 11, offset: 3794},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 151, col: 11, offset: 3248},
+							pos:   position{line: 167, col: 11, offset: 3794},
 							label: "n",
 							expr: &ruleRefExpr{
-								pos:  position{line: 151, col: 14, offset: 3251},
+								pos:  position{line: 167, col: 14, offset: 3797},
 								name: "IDENT",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 151, col: 21, offset: 3258},
+							pos:  position{line: 167, col: 21, offset: 3804},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 151, col: 24, offset: 3261},
+							pos:        position{line: 167, col: 24, offset: 3807},
 							val:        "=",
 							ignoreCase: false,
 							want:       "\"=\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 151, col: 28, offset: 3265},
+							pos:  position{line: 167, col: 28, offset: 3811},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 151, col: 31, offset: 3268},
+							pos:   position{line: 167, col: 31, offset: 3814},
 							label: "v",
 							expr: &choiceExpr{
-								pos: position{line: 151, col: 34, offset: 3271},
+								pos: position{line: 167, col: 34, offset: 3817},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 151, col: 34, offset: 3271},
+										pos:  position{line: 167, col: 34, offset: 3817},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 151, col: 45, offset: 3282},
+										pos:  position{line: 167, col: 45, offset: 3828},
 										name: "CHAIN",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 151, col: 53, offset: 3290},
+										pos:  position{line: 167, col: 53, offset: 3836},
 										name: "String",
 									},
 								},
@@ -1804,19 +2021,19 @@ var g = &grammar{
 		},
 		{
 			name: "HIDDEN_RULE",
-			pos:  position{line: 155, col: 1, offset: 3327},
+			pos:  position{line: 171, col: 1, offset: 3873},
 			expr: &actionExpr{
-				pos: position{line: 155, col: 16, offset: 3342},
+				pos: position{line: 171, col: 16, offset: 3888},
 				run: (*parser).callonHIDDEN_RULE1,
 				expr: &seqExpr{
-					pos: position{line: 155, col: 16, offset: 3342},
+					pos: position{line: 171, col: 16, offset: 3888},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 155, col: 16, offset: 3342},
+							pos:  position{line: 171, col: 16, offset: 3888},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 155, col: 24, offset: 3350},
+							pos:        position{line: 171, col: 24, offset: 3896},
 							val:        "hidden",
 							ignoreCase: false,
 							want:       "\"hidden\"",
@@ -1827,39 +2044,93 @@ var g = &grammar{
 		},
 		{
 			name: "TIMEOUT",
-			pos:  position{line: 159, col: 1, offset: 3384},
+			pos:  position{line: 175, col: 1, offset: 3930},
 			expr: &actionExpr{
-				pos: position{line: 159, col: 12, offset: 3395},
+				pos: position{line: 175, col: 12, offset: 3941},
 				run: (*parser).callonTIMEOUT1,
 				expr: &seqExpr{
-					pos: position{line: 159, col: 12, offset: 3395},
+					pos: position{line: 175, col: 12, offset: 3941},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 159, col: 12, offset: 3395},
+							pos:  position{line: 175, col: 12, offset: 3941},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 159, col: 20, offset: 3403},
+							pos:        position{line: 175, col: 20, offset: 3949},
 							val:        "timeout",
 							ignoreCase: false,
 							want:       "\"timeout\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 159, col: 30, offset: 3413},
+							pos:  position{line: 175, col: 30, offset: 3959},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 159, col: 38, offset: 3421},
+							pos:   position{line: 175, col: 38, offset: 3967},
 							label: "t",
 							expr: &choiceExpr{
-								pos: position{line: 159, col: 41, offset: 3424},
+								pos: position{line: 175, col: 41, offset: 3970},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 159, col: 41, offset: 3424},
+										pos:  position{line: 175, col: 41, offset: 3970},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 159, col: 52, offset: 3435},
+										pos:  position{line: 175, col: 52, offset: 3981},
+										name: "Integer",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "HEDGE",
+			pos:  position{line: 179, col: 1, offset: 4017},
+			expr: &actionExpr{
+				pos: position{line: 179, col: 10, offset: 4026},
+				run: (*parser).callonHEDGE1,
+				expr: &seqExpr{
+					pos: position{line: 179, col: 10, offset: 4026},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 179, col: 10, offset: 4026},
+							name: "WS_MAND",
+						},
+						&litMatcher{
+							pos:        position{line: 179, col: 18, offset: 4034},
+							val:        "hedge",
+							ignoreCase: false,
+							want:       "\"hedge\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 179, col: 26, offset: 4042},
+							name: "WS_MAND",
+						},
+						&litMatcher{
+							pos:        position{line: 179, col: 34, offset: 4050},
+							val:        "after",
+							ignoreCase: false,
+							want:       "\"after\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 179, col: 42, offset: 4058},
+							name: "WS_MAND",
+						},
+						&labeledExpr{
+							pos:   position{line: 179, col: 50, offset: 4066},
+							label: "t",
+							expr: &choiceExpr{
+								pos: position{line: 179, col: 53, offset: 4069},
+								alternatives: []interface{}{
+									&ruleRefExpr{
+										pos:  position{line: 179, col: 53, offset: 4069},
+										name: "VARIABLE",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 179, col: 64, offset: 4080},
 										name: "Integer",
 									},
 								},
@@ -1871,39 +2142,39 @@ var g = &grammar{
 		},
 		{
 			name: "MAX_AGE",
-			pos:  position{line: 163, col: 1, offset: 3471},
+			pos:  position{line: 183, col: 1, offset: 4114},
 			expr: &actionExpr{
-				pos: position{line: 163, col: 12, offset: 3482},
+				pos: position{line: 183, col: 12, offset: 4125},
 				run: (*parser).callonMAX_AGE1,
 				expr: &seqExpr{
-					pos: position{line: 163, col: 12, offset: 3482},
+					pos: position{line: 183, col: 12, offset: 4125},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 163, col: 12, offset: 3482},
+							pos:  position{line: 183, col: 12, offset: 4125},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 163, col: 20, offset: 3490},
+							pos:        position{line: 183, col: 20, offset: 4133},
 							val:        "max-age",
 							ignoreCase: false,
 							want:       "\"max-age\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 163, col: 30, offset: 3500},
+							pos:  position{line: 183, col: 30, offset: 4143},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 163, col: 38, offset: 3508},
+							pos:   position{line: 183, col: 38, offset: 4151},
 							label: "t",
 							expr: &choiceExpr{
-								pos: position{line: 163, col: 41, offset: 3511},
+								pos: position{line: 183, col: 41, offset: 4154},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 163, col: 41, offset: 3511},
+										pos:  position{line: 183, col: 41, offset: 4154},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 163, col: 52, offset: 3522},
+										pos:  position{line: 183, col: 52, offset: 4165},
 										name: "Integer",
 									},
 								},
@@ -1915,39 +2186,127 @@ var g = &grammar{
 		},
 		{
 			name: "S_MAX_AGE",
-			pos:  position{line: 167, col: 1, offset: 3557},
+			pos:  position{line: 187, col: 1, offset: 4200},
 			expr: &actionExpr{
-				pos: position{line: 167, col: 14, offset: 3570},
+				pos: position{line: 187, col: 14, offset: 4213},
 				run: (*parser).callonS_MAX_AGE1,
 				expr: &seqExpr{
-					pos: position{line: 167, col: 14, offset: 3570},
+					pos: position{line: 187, col: 14, offset: 4213},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 167, col: 14, offset: 3570},
+							pos:  position{line: 187, col: 14, offset: 4213},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 167, col: 22, offset: 3578},
+							pos:        position{line: 187, col: 22, offset: 4221},
 							val:        "s-max-age",
 							ignoreCase: false,
 							want:       "\"s-max-age\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 167, col: 34, offset: 3590},
+							pos:  position{line: 187, col: 34, offset: 4233},
+							name: "WS_MAND",
+						},
+						&labeledExpr{
+							pos:   position{line: 187, col: 42, offset: 4241},
+							label: "t",
+							expr: &choiceExpr{
+								pos: position{line: 187, col: 45, offset: 4244},
+								alternatives: []interface{}{
+									&ruleRefExpr{
+										pos:  position{line: 187, col: 45, offset: 4244},
+										name: "VARIABLE",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 187, col: 56, offset: 4255},
+										name: "Integer",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "STALE_WHILE_REVALIDATE",
+			pos:  position{line: 191, col: 1, offset: 4291},
+			expr: &actionExpr{
+				pos: position{line: 191, col: 27, offset: 4317},
+				run: (*parser).callonSTALE_WHILE_REVALIDATE1,
+				expr: &seqExpr{
+					pos: position{line: 191, col: 27, offset: 4317},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 191, col: 27, offset: 4317},
+							name: "WS_MAND",
+						},
+						&litMatcher{
+							pos:        position{line: 191, col: 35, offset: 4325},
+							val:        "stale-while-revalidate",
+							ignoreCase: false,
+							want:       "\"stale-while-revalidate\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 191, col: 60, offset: 4350},
+							name: "WS_MAND",
+						},
+						&labeledExpr{
+							pos:   position{line: 191, col: 68, offset: 4358},
+							label: "t",
+							expr: &choiceExpr{
+								pos: position{line: 191, col: 71, offset: 4361},
+								alternatives: []interface{}{
+									&ruleRefExpr{
+										pos:  position{line: 191, col: 71, offset: 4361},
+										name: "VARIABLE",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 191, col: 82, offset: 4372},
+										name: "Integer",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "STALE_IF_ERROR",
+			pos:  position{line: 195, col: 1, offset: 4421},
+			expr: &actionExpr{
+				pos: position{line: 195, col: 19, offset: 4439},
+				run: (*parser).callonSTALE_IF_ERROR1,
+				expr: &seqExpr{
+					pos: position{line: 195, col: 19, offset: 4439},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 195, col: 19, offset: 4439},
+							name: "WS_MAND",
+						},
+						&litMatcher{
+							pos:        position{line: 195, col: 27, offset: 4447},
+							val:        "stale-if-error",
+							ignoreCase: false,
+							want:       "\"stale-if-error\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 195, col: 44, offset: 4464},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 167, col: 42, offset: 3598},
+							pos:   position{line: 195, col: 52, offset: 4472},
 							label: "t",
 							expr: &choiceExpr{
-								pos: position{line: 167, col: 45, offset: 3601},
+								pos: position{line: 195, col: 55, offset: 4475},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 167, col: 45, offset: 3601},
+										pos:  position{line: 195, col: 55, offset: 4475},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 167, col: 56, offset: 3612},
+										pos:  position{line: 195, col: 66, offset: 4486},
 										name: "Integer",
 									},
 								},
@@ -1957,34 +2316,102 @@ var g = &grammar{
 				},
 			},
 		},
+		{
+			name: "CACHE_FLAG",
+			pos:  position{line: 199, col: 1, offset: 4527},
+			expr: &actionExpr{
+				pos: position{line: 199, col: 15, offset: 4541},
+				run: (*parser).callonCACHE_FLAG1,
+				expr: &seqExpr{
+					pos: position{line: 199, col: 15, offset: 4541},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 199, col: 15, offset: 4541},
+							name: "WS_MAND",
+						},
+						&labeledExpr{
+							pos:   position{line: 199, col: 23, offset: 4549},
+							label: "f",
+							expr: &ruleRefExpr{
+								pos:  position{line: 199, col: 26, offset: 4552},
+								name: "CACHE_DIRECTIVE",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "CACHE_DIRECTIVE",
+			pos:  position{line: 203, col: 1, offset: 4598},
+			expr: &actionExpr{
+				pos: position{line: 203, col: 20, offset: 4617},
+				run: (*parser).callonCACHE_DIRECTIVE1,
+				expr: &choiceExpr{
+					pos: position{line: 203, col: 21, offset: 4618},
+					alternatives: []interface{}{
+						&litMatcher{
+							pos:        position{line: 203, col: 21, offset: 4618},
+							val:        "private",
+							ignoreCase: false,
+							want:       "\"private\"",
+						},
+						&litMatcher{
+							pos:        position{line: 203, col: 33, offset: 4630},
+							val:        "public",
+							ignoreCase: false,
+							want:       "\"public\"",
+						},
+						&litMatcher{
+							pos:        position{line: 203, col: 44, offset: 4641},
+							val:        "no-store",
+							ignoreCase: false,
+							want:       "\"no-store\"",
+						},
+						&litMatcher{
+							pos:        position{line: 203, col: 57, offset: 4654},
+							val:        "no-cache",
+							ignoreCase: false,
+							want:       "\"no-cache\"",
+						},
+						&litMatcher{
+							pos:        position{line: 203, col: 70, offset: 4667},
+							val:        "must-revalidate",
+							ignoreCase: false,
+							want:       "\"must-revalidate\"",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "DEPENDS_ON",
-			pos:  position{line: 172, col: 1, offset: 3649},
+			pos:  position{line: 208, col: 1, offset: 4718},
 			expr: &actionExpr{
-				pos: position{line: 172, col: 15, offset: 3663},
+				pos: position{line: 208, col: 15, offset: 4732},
 				run: (*parser).callonDEPENDS_ON1,
 				expr: &seqExpr{
-					pos: position{line: 172, col: 15, offset: 3663},
+					pos: position{line: 208, col: 15, offset: 4732},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 172, col: 15, offset: 3663},
+							pos:  position{line: 208, col: 15, offset: 4732},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 172, col: 23, offset: 3671},
+							pos:        position{line: 208, col: 23, offset: 4740},
 							val:        "depends-on",
 							ignoreCase: false,
 							want:       "\"depends-on\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 172, col: 36, offset: 3684},
+							pos:  position{line: 208, col: 36, offset: 4753},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 172, col: 44, offset: 3692},
+							pos:   position{line: 208, col: 44, offset: 4761},
 							label: "t",
 							expr: &ruleRefExpr{
-								pos:  position{line: 172, col: 47, offset: 3695},
+								pos:  position{line: 208, col: 47, offset: 4764},
 								name: "IDENT",
 							},
 						},
@@ -1994,48 +2421,48 @@ var g = &grammar{
 		},
 		{
 			name: "FLAGS_RULE",
-			pos:  position{line: 176, col: 1, offset: 3731},
+			pos:  position{line: 212, col: 1, offset: 4800},
 			expr: &actionExpr{
-				pos: position{line: 176, col: 15, offset: 3745},
+				pos: position{line: 212, col: 15, offset: 4814},
 				run: (*parser).callonFLAGS_RULE1,
 				expr: &seqExpr{
-					pos: position{line: 176, col: 15, offset: 3745},
+					pos: position{line: 212, col: 15, offset: 4814},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 176, col: 15, offset: 3745},
+							pos:  position{line: 212, col: 15, offset: 4814},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 176, col: 23, offset: 3753},
+							pos:   position{line: 212, col: 23, offset: 4822},
 							label: "i",
 							expr: &ruleRefExpr{
-								pos:  position{line: 176, col: 25, offset: 3755},
-								name: "IGNORE_FLAG",
+								pos:  position{line: 212, col: 25, offset: 4824},
+								name: "FLAG",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 176, col: 37, offset: 3767},
+							pos:   position{line: 212, col: 30, offset: 4829},
 							label: "is",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 176, col: 40, offset: 3770},
+								pos: position{line: 212, col: 33, offset: 4832},
 								expr: &seqExpr{
-									pos: position{line: 176, col: 41, offset: 3771},
+									pos: position{line: 212, col: 34, offset: 4833},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 176, col: 41, offset: 3771},
+											pos:  position{line: 212, col: 34, offset: 4833},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 176, col: 44, offset: 3774},
+											pos:  position{line: 212, col: 37, offset: 4836},
 											name: "LS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 176, col: 47, offset: 3777},
+											pos:  position{line: 212, col: 40, offset: 4839},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 176, col: 50, offset: 3780},
-											name: "IGNORE_FLAG",
+											pos:  position{line: 212, col: 43, offset: 4842},
+											name: "FLAG",
 										},
 									},
 								},
@@ -2045,56 +2472,187 @@ var g = &grammar{
 				},
 			},
 		},
+		{
+			name: "FLAG",
+			pos:  position{line: 216, col: 1, offset: 4878},
+			expr: &choiceExpr{
+				pos: position{line: 216, col: 9, offset: 4886},
+				alternatives: []interface{}{
+					&ruleRefExpr{
+						pos:  position{line: 216, col: 9, offset: 4886},
+						name: "IGNORE_FLAG",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 216, col: 23, offset: 4900},
+						name: "SUCCESS_ON",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 216, col: 36, offset: 4913},
+						name: "RAW_FLAG",
+					},
+				},
+			},
+		},
 		{
 			name: "IGNORE_FLAG",
-			pos:  position{line: 180, col: 1, offset: 3823},
+			pos:  position{line: 218, col: 1, offset: 4923},
 			expr: &actionExpr{
-				pos: position{line: 180, col: 16, offset: 3838},
+				pos: position{line: 218, col: 16, offset: 4938},
 				run: (*parser).callonIGNORE_FLAG1,
+				expr: &seqExpr{
+					pos: position{line: 218, col: 16, offset: 4938},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 218, col: 16, offset: 4938},
+							val:        "ignore-errors",
+							ignoreCase: false,
+							want:       "\"ignore-errors\"",
+						},
+						&labeledExpr{
+							pos:   position{line: 218, col: 32, offset: 4954},
+							label: "codes",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 218, col: 39, offset: 4961},
+								expr: &ruleRefExpr{
+									pos:  position{line: 218, col: 39, offset: 4961},
+									name: "STATUS_CODES",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "SUCCESS_ON",
+			pos:  position{line: 222, col: 1, offset: 5012},
+			expr: &actionExpr{
+				pos: position{line: 222, col: 15, offset: 5026},
+				run: (*parser).callonSUCCESS_ON1,
+				expr: &seqExpr{
+					pos: position{line: 222, col: 15, offset: 5026},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 222, col: 15, offset: 5026},
+							val:        "success-on",
+							ignoreCase: false,
+							want:       "\"success-on\"",
+						},
+						&labeledExpr{
+							pos:   position{line: 222, col: 28, offset: 5039},
+							label: "codes",
+							expr: &ruleRefExpr{
+								pos:  position{line: 222, col: 34, offset: 5045},
+								name: "STATUS_CODES",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "RAW_FLAG",
+			pos:  position{line: 226, col: 1, offset: 5091},
+			expr: &actionExpr{
+				pos: position{line: 226, col: 13, offset: 5103},
+				run: (*parser).callonRAW_FLAG1,
 				expr: &litMatcher{
-					pos:        position{line: 180, col: 16, offset: 3838},
-					val:        "ignore-errors",
+					pos:        position{line: 226, col: 13, offset: 5103},
+					val:        "raw",
 					ignoreCase: false,
-					want:       "\"ignore-errors\"",
+					want:       "\"raw\"",
+				},
+			},
+		},
+		{
+			name: "STATUS_CODES",
+			pos:  position{line: 230, col: 1, offset: 5131},
+			expr: &actionExpr{
+				pos: position{line: 230, col: 17, offset: 5147},
+				run: (*parser).callonSTATUS_CODES1,
+				expr: &seqExpr{
+					pos: position{line: 230, col: 17, offset: 5147},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 230, col: 17, offset: 5147},
+							name: "WS_MAND",
+						},
+						&labeledExpr{
+							pos:   position{line: 230, col: 25, offset: 5155},
+							label: "s",
+							expr: &ruleRefExpr{
+								pos:  position{line: 230, col: 27, offset: 5157},
+								name: "Integer",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 230, col: 35, offset: 5165},
+							label: "ss",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 230, col: 38, offset: 5168},
+								expr: &seqExpr{
+									pos: position{line: 230, col: 39, offset: 5169},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 230, col: 39, offset: 5169},
+											name: "WS",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 230, col: 42, offset: 5172},
+											name: "LS",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 230, col: 45, offset: 5175},
+											name: "WS",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 230, col: 48, offset: 5178},
+											name: "Integer",
+										},
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		{
 			name: "CHAIN",
-			pos:  position{line: 184, col: 1, offset: 3885},
+			pos:  position{line: 234, col: 1, offset: 5223},
 			expr: &actionExpr{
-				pos: position{line: 184, col: 10, offset: 3894},
+				pos: position{line: 234, col: 10, offset: 5232},
 				run: (*parser).callonCHAIN1,
 				expr: &seqExpr{
-					pos: position{line: 184, col: 10, offset: 3894},
+					pos: position{line: 234, col: 10, offset: 5232},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 184, col: 10, offset: 3894},
+							pos:   position{line: 234, col: 10, offset: 5232},
 							label: "i",
 							expr: &ruleRefExpr{
-								pos:  position{line: 184, col: 13, offset: 3897},
+								pos:  position{line: 234, col: 13, offset: 5235},
 								name: "CHAINED_ITEM",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 184, col: 27, offset: 3911},
+							pos:   position{line: 234, col: 27, offset: 5249},
 							label: "ii",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 184, col: 30, offset: 3914},
+								pos: position{line: 234, col: 30, offset: 5252},
 								expr: &seqExpr{
-									pos: position{line: 184, col: 31, offset: 3915},
+									pos: position{line: 234, col: 31, offset: 5253},
 									exprs: []interface{}{
 										&zeroOrOneExpr{
-											pos: position{line: 184, col: 31, offset: 3915},
+											pos: position{line: 234, col: 31, offset: 5253},
 											expr: &litMatcher{
-												pos:        position{line: 184, col: 31, offset: 3915},
+												pos:        position{line: 234, col: 31, offset: 5253},
 												val:        ".",
 												ignoreCase: false,
 												want:       "\".\"",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 184, col: 36, offset: 3920},
+											pos:  position{line: 234, col: 36, offset: 5258},
 											name: "CHAINED_ITEM",
 										},
 									},
@@ -2107,22 +2665,22 @@ var g = &grammar{
 		},
 		{
 			name: "CHAINED_ITEM",
-			pos:  position{line: 188, col: 1, offset: 3964},
+			pos:  position{line: 238, col: 1, offset: 5302},
 			expr: &actionExpr{
-				pos: position{line: 188, col: 17, offset: 3980},
+				pos: position{line: 238, col: 17, offset: 5318},
 				run: (*parser).callonCHAINED_ITEM1,
 				expr: &labeledExpr{
-					pos:   position{line: 188, col: 17, offset: 3980},
+					pos:   position{line: 238, col: 17, offset: 5318},
 					label: "ci",
 					expr: &choiceExpr{
-						pos: position{line: 188, col: 21, offset: 3984},
+						pos: position{line: 238, col: 21, offset: 5322},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 188, col: 21, offset: 3984},
+								pos:  position{line: 238, col: 21, offset: 5322},
 								name: "PATH_VARIABLE",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 188, col: 37, offset: 4000},
+								pos:  position{line: 238, col: 37, offset: 5338},
 								name: "IDENT",
 							},
 						},
@@ -2132,40 +2690,40 @@ var g = &grammar{
 		},
 		{
 			name: "PATH_VARIABLE",
-			pos:  position{line: 192, col: 1, offset: 4035},
+			pos:  position{line: 242, col: 1, offset: 5373},
 			expr: &actionExpr{
-				pos: position{line: 192, col: 18, offset: 4052},
+				pos: position{line: 242, col: 18, offset: 5390},
 				run: (*parser).callonPATH_VARIABLE1,
 				expr: &seqExpr{
-					pos: position{line: 192, col: 18, offset: 4052},
+					pos: position{line: 242, col: 18, offset: 5390},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 192, col: 18, offset: 4052},
+							pos: position{line: 242, col: 18, offset: 5390},
 							expr: &litMatcher{
-								pos:        position{line: 192, col: 18, offset: 4052},
+								pos:        position{line: 242, col: 18, offset: 5390},
 								val:        "[",
 								ignoreCase: false,
 								want:       "\"[\"",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 192, col: 23, offset: 4057},
+							pos:        position{line: 242, col: 23, offset: 5395},
 							val:        "$",
 							ignoreCase: false,
 							want:       "\"$\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 192, col: 27, offset: 4061},
+							pos:   position{line: 242, col: 27, offset: 5399},
 							label: "i",
 							expr: &ruleRefExpr{
-								pos:  position{line: 192, col: 30, offset: 4064},
+								pos:  position{line: 242, col: 30, offset: 5402},
 								name: "IDENT",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 192, col: 37, offset: 4071},
+							pos: position{line: 242, col: 37, offset: 5409},
 							expr: &litMatcher{
-								pos:        position{line: 192, col: 37, offset: 4071},
+								pos:        position{line: 242, col: 37, offset: 5409},
 								val:        "]",
 								ignoreCase: false,
 								want:       "\"]\"",
@@ -2177,24 +2735,24 @@ var g = &grammar{
 		},
 		{
 			name: "VARIABLE",
-			pos:  position{line: 196, col: 1, offset: 4113},
+			pos:  position{line: 246, col: 1, offset: 5451},
 			expr: &actionExpr{
-				pos: position{line: 196, col: 13, offset: 4125},
+				pos: position{line: 246, col: 13, offset: 5463},
 				run: (*parser).callonVARIABLE1,
 				expr: &seqExpr{
-					pos: position{line: 196, col: 13, offset: 4125},
+					pos: position{line: 246, col: 13, offset: 5463},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 196, col: 13, offset: 4125},
+							pos:        position{line: 246, col: 13, offset: 5463},
 							val:        "$",
 							ignoreCase: false,
 							want:       "\"$\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 196, col: 17, offset: 4129},
+							pos:   position{line: 246, col: 17, offset: 5467},
 							label: "v",
 							expr: &ruleRefExpr{
-								pos:  position{line: 196, col: 20, offset: 4132},
+								pos:  position{line: 246, col: 20, offset: 5470},
 								name: "IDENT_WITH_DOT",
 							},
 						},
@@ -2204,14 +2762,14 @@ var g = &grammar{
 		},
 		{
 			name: "IDENT",
-			pos:  position{line: 200, col: 1, offset: 4176},
+			pos:  position{line: 250, col: 1, offset: 5514},
 			expr: &actionExpr{
-				pos: position{line: 200, col: 10, offset: 4185},
+				pos: position{line: 250, col: 10, offset: 5523},
 				run: (*parser).callonIDENT1,
 				expr: &oneOrMoreExpr{
-					pos: position{line: 200, col: 10, offset: 4185},
+					pos: position{line: 250, col: 10, offset: 5523},
 					expr: &charClassMatcher{
-						pos:        position{line: 200, col: 10, offset: 4185},
+						pos:        position{line: 250, col: 10, offset: 5523},
 						val:        "[A-Za-z0-9:_-]",
 						chars:      []rune{':', '_', '-'},
 						ranges:     []rune{'A', 'Z', 'a', 'z', '0', '9'},
@@ -2223,14 +2781,14 @@ var g = &grammar{
 		},
 		{
 			name: "IDENT_WITHOUT_COLLON",
-			pos:  position{line: 204, col: 1, offset: 4232},
+			pos:  position{line: 254, col: 1, offset: 5570},
 			expr: &actionExpr{
-				pos: position{line: 204, col: 25, offset: 4256},
+				pos: position{line: 254, col: 25, offset: 5594},
 				run: (*parser).callonIDENT_WITHOUT_COLLON1,
 				expr: &oneOrMoreExpr{
-					pos: position{line: 204, col: 25, offset: 4256},
+					pos: position{line: 254, col: 25, offset: 5594},
 					expr: &charClassMatcher{
-						pos:        position{line: 204, col: 25, offset: 4256},
+						pos:        position{line: 254, col: 25, offset: 5594},
 						val:        "[A-Za-z0-9_-]",
 						chars:      []rune{'_', '-'},
 						ranges:     []rune{'A', 'Z', 'a', 'z', '0', '9'},
@@ -2242,14 +2800,14 @@ var g = &grammar{
 		},
 		{
 			name: "IDENT_WITH_DOT",
-			pos:  position{line: 208, col: 1, offset: 4302},
+			pos:  position{line: 258, col: 1, offset: 5640},
 			expr: &actionExpr{
-				pos: position{line: 208, col: 19, offset: 4320},
+				pos: position{line: 258, col: 19, offset: 5658},
 				run: (*parser).callonIDENT_WITH_DOT1,
 				expr: &oneOrMoreExpr{
-					pos: position{line: 208, col: 19, offset: 4320},
+					pos: position{line: 258, col: 19, offset: 5658},
 					expr: &charClassMatcher{
-						pos:        position{line: 208, col: 19, offset: 4320},
+						pos:        position{line: 258, col: 19, offset: 5658},
 						val:        "[a-zA-Z0-9-:_.]",
 						chars:      []rune{'-', ':', '_', '.'},
 						ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
@@ -2261,12 +2819,12 @@ var g = &grammar{
 		},
 		{
 			name: "Null",
-			pos:  position{line: 212, col: 1, offset: 4368},
+			pos:  position{line: 262, col: 1, offset: 5706},
 			expr: &actionExpr{
-				pos: position{line: 212, col: 9, offset: 4376},
+				pos: position{line: 262, col: 9, offset: 5714},
 				run: (*parser).callonNull1,
 				expr: &litMatcher{
-					pos:        position{line: 212, col: 9, offset: 4376},
+					pos:        position{line: 262, col: 9, offset: 5714},
 					val:        "null",
 					ignoreCase: false,
 					want:       "\"null\"",
@@ -2275,21 +2833,21 @@ var g = &grammar{
 		},
 		{
 			name: "Boolean",
-			pos:  position{line: 216, col: 1, offset: 4406},
+			pos:  position{line: 266, col: 1, offset: 5744},
 			expr: &actionExpr{
-				pos: position{line: 216, col: 12, offset: 4417},
+				pos: position{line: 266, col: 12, offset: 5755},
 				run: (*parser).callonBoolean1,
 				expr: &choiceExpr{
-					pos: position{line: 216, col: 13, offset: 4418},
+					pos: position{line: 266, col: 13, offset: 5756},
 					alternatives: []interface{}{
 						&litMatcher{
-							pos:        position{line: 216, col: 13, offset: 4418},
+							pos:        position{line: 266, col: 13, offset: 5756},
 							val:        "true",
 							ignoreCase: false,
 							want:       "\"true\"",
 						},
 						&litMatcher{
-							pos:        position{line: 216, col: 22, offset: 4427},
+							pos:        position{line: 266, col: 22, offset: 5765},
 							val:        "false",
 							ignoreCase: false,
 							want:       "\"false\"",
@@ -2300,41 +2858,41 @@ var g = &grammar{
 		},
 		{
 			name: "String",
-			pos:  position{line: 220, col: 1, offset: 4468},
+			pos:  position{line: 270, col: 1, offset: 5806},
 			expr: &actionExpr{
-				pos: position{line: 220, col: 11, offset: 4478},
+				pos: position{line: 270, col: 11, offset: 5816},
 				run: (*parser).callonString1,
 				expr: &seqExpr{
-					pos: position{line: 220, col: 11, offset: 4478},
+					pos: position{line: 270, col: 11, offset: 5816},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 220, col: 11, offset: 4478},
+							pos:        position{line: 270, col: 11, offset: 5816},
 							val:        "\"",
 							ignoreCase: false,
 							want:       "\"\\\"\"",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 220, col: 15, offset: 4482},
+							pos: position{line: 270, col: 15, offset: 5820},
 							expr: &seqExpr{
-								pos: position{line: 220, col: 17, offset: 4484},
+								pos: position{line: 270, col: 17, offset: 5822},
 								exprs: []interface{}{
 									&notExpr{
-										pos: position{line: 220, col: 17, offset: 4484},
+										pos: position{line: 270, col: 17, offset: 5822},
 										expr: &litMatcher{
-											pos:        position{line: 220, col: 18, offset: 4485},
+											pos:        position{line: 270, col: 18, offset: 5823},
 											val:        "\"",
 											ignoreCase: false,
 											want:       "\"\\\"\"",
 										},
 									},
 									&anyMatcher{
-										line: 220, col: 22, offset: 4489,
+										line: 270, col: 22, offset: 5827,
 									},
 								},
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 220, col: 27, offset: 4494},
+							pos:        position{line: 270, col: 27, offset: 5832},
 							val:        "\"",
 							ignoreCase: false,
 							want:       "\"\\\"\"",
@@ -2345,26 +2903,26 @@ var g = &grammar{
 		},
 		{
 			name: "Float",
-			pos:  position{line: 224, col: 1, offset: 4529},
+			pos:  position{line: 274, col: 1, offset: 5867},
 			expr: &actionExpr{
-				pos: position{line: 224, col: 10, offset: 4538},
+				pos: position{line: 274, col: 10, offset: 5876},
 				run: (*parser).callonFloat1,
 				expr: &seqExpr{
-					pos: position{line: 224, col: 10, offset: 4538},
+					pos: position{line: 274, col: 10, offset: 5876},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 224, col: 10, offset: 4538},
+							pos: position{line: 274, col: 10, offset: 5876},
 							expr: &choiceExpr{
-								pos: position{line: 224, col: 11, offset: 4539},
+								pos: position{line: 274, col: 11, offset: 5877},
 								alternatives: []interface{}{
 									&litMatcher{
-										pos:        position{line: 224, col: 11, offset: 4539},
+										pos:        position{line: 274, col: 11, offset: 5877},
 										val:        "+",
 										ignoreCase: false,
 										want:       "\"+\"",
 									},
 									&litMatcher{
-										pos:        position{line: 224, col: 17, offset: 4545},
+										pos:        position{line: 274, col: 17, offset: 5883},
 										val:        "-",
 										ignoreCase: false,
 										want:       "\"-\"",
@@ -2373,17 +2931,17 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 224, col: 23, offset: 4551},
+							pos:  position{line: 274, col: 23, offset: 5889},
 							name: "Natural",
 						},
 						&litMatcher{
-							pos:        position{line: 224, col: 31, offset: 4559},
+							pos:        position{line: 274, col: 31, offset: 5897},
 							val:        ".",
 							ignoreCase: false,
 							want:       "\".\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 224, col: 35, offset: 4563},
+							pos:  position{line: 274, col: 35, offset: 5901},
 							name: "Natural",
 						},
 					},
@@ -2392,26 +2950,26 @@ var g = &grammar{
 		},
 		{
 			name: "Integer",
-			pos:  position{line: 228, col: 1, offset: 4601},
+			pos:  position{line: 278, col: 1, offset: 5939},
 			expr: &actionExpr{
-				pos: position{line: 228, col: 12, offset: 4612},
+				pos: position{line: 278, col: 12, offset: 5950},
 				run: (*parser).callonInteger1,
 				expr: &seqExpr{
-					pos: position{line: 228, col: 12, offset: 4612},
+					pos: position{line: 278, col: 12, offset: 5950},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 228, col: 12, offset: 4612},
+							pos: position{line: 278, col: 12, offset: 5950},
 							expr: &choiceExpr{
-								pos: position{line: 228, col: 13, offset: 4613},
+								pos: position{line: 278, col: 13, offset: 5951},
 								alternatives: []interface{}{
 									&litMatcher{
-										pos:        position{line: 228, col: 13, offset: 4613},
+										pos:        position{line: 278, col: 13, offset: 5951},
 										val:        "+",
 										ignoreCase: false,
 										want:       "\"+\"",
 									},
 									&litMatcher{
-										pos:        position{line: 228, col: 19, offset: 4619},
+										pos:        position{line: 278, col: 19, offset: 5957},
 										val:        "-",
 										ignoreCase: false,
 										want:       "\"-\"",
@@ -2420,7 +2978,7 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 228, col: 25, offset: 4625},
+							pos:  position{line: 278, col: 25, offset: 5963},
 							name: "Natural",
 						},
 					},
@@ -2429,27 +2987,27 @@ var g = &grammar{
 		},
 		{
 			name: "Natural",
-			pos:  position{line: 232, col: 1, offset: 4665},
+			pos:  position{line: 282, col: 1, offset: 6003},
 			expr: &choiceExpr{
-				pos: position{line: 232, col: 11, offset: 4677},
+				pos: position{line: 282, col: 11, offset: 6015},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 232, col: 11, offset: 4677},
+						pos:        position{line: 282, col: 11, offset: 6015},
 						val:        "0",
 						ignoreCase: false,
 						want:       "\"0\"",
 					},
 					&seqExpr{
-						pos: position{line: 232, col: 17, offset: 4683},
+						pos: position{line: 282, col: 17, offset: 6021},
 						exprs: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 232, col: 17, offset: 4683},
+								pos:  position{line: 282, col: 17, offset: 6021},
 								name: "NonZeroDecimalDigit",
 							},
 							&zeroOrMoreExpr{
-								pos: position{line: 232, col: 37, offset: 4703},
+								pos: position{line: 282, col: 37, offset: 6041},
 								expr: &ruleRefExpr{
-									pos:  position{line: 232, col: 37, offset: 4703},
+									pos:  position{line: 282, col: 37, offset: 6041},
 									name: "DecimalDigit",
 								},
 							},
@@ -2460,9 +3018,9 @@ var g = &grammar{
 		},
 		{
 			name: "DecimalDigit",
-			pos:  position{line: 234, col: 1, offset: 4718},
+			pos:  position{line: 284, col: 1, offset: 6056},
 			expr: &charClassMatcher{
-				pos:        position{line: 234, col: 16, offset: 4735},
+				pos:        position{line: 284, col: 16, offset: 6073},
 				val:        "[0-9]",
 				ranges:     []rune{'0', '9'},
 				ignoreCase: false,
@@ -2471,9 +3029,9 @@ var g = &grammar{
 		},
 		{
 			name: "NonZeroDecimalDigit",
-			pos:  position{line: 235, col: 1, offset: 4741},
+			pos:  position{line: 285, col: 1, offset: 6079},
 			expr: &charClassMatcher{
-				pos:        position{line: 235, col: 23, offset: 4765},
+				pos:        position{line: 285, col: 23, offset: 6103},
 				val:        "[1-9]",
 				ranges:     []rune{'1', '9'},
 				ignoreCase: false,
@@ -2482,9 +3040,9 @@ var g = &grammar{
 		},
 		{
 			name: "SPACE",
-			pos:  position{line: 237, col: 1, offset: 4772},
+			pos:  position{line: 287, col: 1, offset: 6110},
 			expr: &charClassMatcher{
-				pos:        position{line: 237, col: 10, offset: 4781},
+				pos:        position{line: 287, col: 10, offset: 6119},
 				val:        "[ \\t]",
 				chars:      []rune{' ', '\t'},
 				ignoreCase: false,
@@ -2494,22 +3052,22 @@ var g = &grammar{
 		{
 			name:        "WS_MAND",
 			displayName: "\"mandatory-whitespace\"",
-			pos:         position{line: 238, col: 1, offset: 4787},
+			pos:         position{line: 288, col: 1, offset: 6125},
 			expr: &oneOrMoreExpr{
-				pos: position{line: 238, col: 35, offset: 4821},
+				pos: position{line: 288, col: 35, offset: 6159},
 				expr: &choiceExpr{
-					pos: position{line: 238, col: 36, offset: 4822},
+					pos: position{line: 288, col: 36, offset: 6160},
 					alternatives: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 238, col: 36, offset: 4822},
+							pos:  position{line: 288, col: 36, offset: 6160},
 							name: "SPACE",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 238, col: 44, offset: 4830},
+							pos:  position{line: 288, col: 44, offset: 6168},
 							name: "COMMENT",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 238, col: 54, offset: 4840},
+							pos:  position{line: 288, col: 54, offset: 6178},
 							name: "NL",
 						},
 					},
@@ -2519,18 +3077,18 @@ var g = &grammar{
 		{
 			name:        "WS",
 			displayName: "\"whitespace\"",
-			pos:         position{line: 239, col: 1, offset: 4845},
+			pos:         position{line: 289, col: 1, offset: 6183},
 			expr: &zeroOrMoreExpr{
-				pos: position{line: 239, col: 20, offset: 4864},
+				pos: position{line: 289, col: 20, offset: 6202},
 				expr: &choiceExpr{
-					pos: position{line: 239, col: 21, offset: 4865},
+					pos: position{line: 289, col: 21, offset: 6203},
 					alternatives: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 239, col: 21, offset: 4865},
+							pos:  position{line: 289, col: 21, offset: 6203},
 							name: "SPACE",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 239, col: 29, offset: 4873},
+							pos:  position{line: 289, col: 29, offset: 6211},
 							name: "COMMENT",
 						},
 					},
@@ -2540,22 +3098,22 @@ var g = &grammar{
 		{
 			name:        "LS",
 			displayName: "\"line-separator\"",
-			pos:         position{line: 240, col: 1, offset: 4883},
+			pos:         position{line: 290, col: 1, offset: 6221},
 			expr: &choiceExpr{
-				pos: position{line: 240, col: 25, offset: 4907},
+				pos: position{line: 290, col: 25, offset: 6245},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 240, col: 25, offset: 4907},
+						pos:  position{line: 290, col: 25, offset: 6245},
 						name: "NL",
 					},
 					&litMatcher{
-						pos:        position{line: 240, col: 30, offset: 4912},
+						pos:        position{line: 290, col: 30, offset: 6250},
 						val:        ",",
 						ignoreCase: false,
 						want:       "\",\"",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 240, col: 36, offset: 4918},
+						pos:  position{line: 290, col: 36, offset: 6256},
 						name: "COMMENT",
 					},
 				},
@@ -2564,31 +3122,31 @@ var g = &grammar{
 		{
 			name:        "BS",
 			displayName: "\"block-separator\"",
-			pos:         position{line: 241, col: 1, offset: 4927},
+			pos:         position{line: 291, col: 1, offset: 6265},
 			expr: &oneOrMoreExpr{
-				pos: position{line: 241, col: 25, offset: 4951},
+				pos: position{line: 291, col: 25, offset: 6289},
 				expr: &seqExpr{
-					pos: position{line: 241, col: 26, offset: 4952},
+					pos: position{line: 291, col: 26, offset: 6290},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 241, col: 26, offset: 4952},
+							pos:  position{line: 291, col: 26, offset: 6290},
 							name: "WS",
 						},
 						&choiceExpr{
-							pos: position{line: 241, col: 30, offset: 4956},
+							pos: position{line: 291, col: 30, offset: 6294},
 							alternatives: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 241, col: 30, offset: 4956},
+									pos:  position{line: 291, col: 30, offset: 6294},
 									name: "NL",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 241, col: 35, offset: 4961},
+									pos:  position{line: 291, col: 35, offset: 6299},
 									name: "COMMENT",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 241, col: 44, offset: 4970},
+							pos:  position{line: 291, col: 44, offset: 6308},
 							name: "WS",
 						},
 					},
@@ -2598,9 +3156,9 @@ var g = &grammar{
 		{
 			name:        "NL",
 			displayName: "\"new-line\"",
-			pos:         position{line: 242, col: 1, offset: 4975},
+			pos:         position{line: 292, col: 1, offset: 6313},
 			expr: &litMatcher{
-				pos:        position{line: 242, col: 18, offset: 4992},
+				pos:        position{line: 292, col: 18, offset: 6330},
 				val:        "\n",
 				ignoreCase: false,
 				want:       "\"\\n\"",
@@ -2608,47 +3166,47 @@ var g = &grammar{
 		},
 		{
 			name: "COMMENT",
-			pos:  position{line: 244, col: 1, offset: 4998},
+			pos:  position{line: 294, col: 1, offset: 6336},
 			expr: &seqExpr{
-				pos: position{line: 244, col: 12, offset: 5009},
+				pos: position{line: 294, col: 12, offset: 6347},
 				exprs: []interface{}{
 					&litMatcher{
-						pos:        position{line: 244, col: 12, offset: 5009},
+						pos:        position{line: 294, col: 12, offset: 6347},
 						val:        "//",
 						ignoreCase: false,
 						want:       "\"//\"",
 					},
 					&zeroOrMoreExpr{
-						pos: position{line: 244, col: 17, offset: 5014},
+						pos: position{line: 294, col: 17, offset: 6352},
 						expr: &seqExpr{
-							pos: position{line: 244, col: 19, offset: 5016},
+							pos: position{line: 294, col: 19, offset: 6354},
 							exprs: []interface{}{
 								&notExpr{
-									pos: position{line: 244, col: 19, offset: 5016},
+									pos: position{line: 294, col: 19, offset: 6354},
 									expr: &litMatcher{
-										pos:        position{line: 244, col: 20, offset: 5017},
+										pos:        position{line: 294, col: 20, offset: 6355},
 										val:        "\n",
 										ignoreCase: false,
 										want:       "\"\\n\"",
 									},
 								},
 								&anyMatcher{
-									line: 244, col: 25, offset: 5022,
+									line: 294, col: 25, offset: 6360,
 								},
 							},
 						},
 					},
 					&choiceExpr{
-						pos: position{line: 244, col: 31, offset: 5028},
+						pos: position{line: 294, col: 31, offset: 6366},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 244, col: 31, offset: 5028},
+								pos:        position{line: 294, col: 31, offset: 6366},
 								val:        "\n",
 								ignoreCase: false,
 								want:       "\"\\n\"",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 244, col: 38, offset: 5035},
+								pos:  position{line: 294, col: 38, offset: 6373},
 								name: "EOF",
 							},
 						},
@@ -2658,11 +3216,11 @@ var g = &grammar{
 		},
 		{
 			name: "EOF",
-			pos:  position{line: 246, col: 1, offset: 5041},
+			pos:  position{line: 296, col: 1, offset: 6379},
 			expr: &notExpr{
-				pos: position{line: 246, col: 8, offset: 5048},
+				pos: position{line: 296, col: 8, offset: 6386},
 				expr: &anyMatcher{
-					line: 246, col: 9, offset: 5049,
+					line: 296, col: 9, offset: 6387,
 				},
 			},
 		},
@@ -2679,14 +3237,34 @@ func (p *parser) callonQUERY1() (interface{}, error) {
 	return p.cur.onQUERY1(stack["us"], stack["firstBlock"], stack["otherBlocks"])
 }
 
-func (c *current) onUSE1(r, v interface{}) (interface{}, error) {
+func (c *current) onUSE2(r, v interface{}) (interface{}, error) {
 	return newUse(r, v)
 }
 
-func (p *parser) callonUSE1() (interface{}, error) {
+func (p *parser) callonUSE2() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onUSE1(stack["r"], stack["v"])
+	return p.cur.onUSE2(stack["r"], stack["v"])
+}
+
+func (c *current) onUSE15(r, v interface{}) (interface{}, error) {
+	return newUse(r, v)
+}
+
+func (p *parser) callonUSE15() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onUSE15(stack["r"], stack["v"])
+}
+
+func (c *current) onUSE28(f interface{}) (interface{}, error) {
+	return newUseFlag(f)
+}
+
+func (p *parser) callonUSE28() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onUSE28(stack["f"])
 }
 
 func (c *current) onUSE_ACTION1() (interface{}, error) {
@@ -2699,6 +3277,16 @@ func (p *parser) callonUSE_ACTION1() (interface{}, error) {
 	return p.cur.onUSE_ACTION1()
 }
 
+func (c *current) onUSE_FLAG1() (interface{}, error) {
+	return stringify(c.text)
+}
+
+func (p *parser) callonUSE_FLAG1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onUSE_FLAG1()
+}
+
 func (c *current) onUSE_VALUE1(v interface{}) (interface{}, error) {
 	return newUseValue(v)
 }
@@ -2709,6 +3297,26 @@ func (p *parser) callonUSE_VALUE1() (interface{}, error) {
 	return p.cur.onUSE_VALUE1(stack["v"])
 }
 
+func (c *current) onUSE_STATUS_ACTION1() (interface{}, error) {
+	return stringify(c.text)
+}
+
+func (p *parser) callonUSE_STATUS_ACTION1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onUSE_STATUS_ACTION1()
+}
+
+func (c *current) onUSE_IDENT_VALUE1(v interface{}) (interface{}, error) {
+	return newUseValue(v)
+}
+
+func (p *parser) callonUSE_IDENT_VALUE1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onUSE_IDENT_VALUE1(stack["v"])
+}
+
 func (c *current) onBLOCK1(action, m, w, f, fl interface{}) (interface{}, error) {
 	return newBlock(action, m, w, f, fl)
 }
@@ -3029,6 +3637,16 @@ func (p *parser) callonTIMEOUT1() (interface{}, error) {
 	return p.cur.onTIMEOUT1(stack["t"])
 }
 
+func (c *current) onHEDGE1(t interface{}) (interface{}, error) {
+	return newHedge(t)
+}
+
+func (p *parser) callonHEDGE1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onHEDGE1(stack["t"])
+}
+
 func (c *current) onMAX_AGE1(t interface{}) (interface{}, error) {
 	return newMaxAge(t)
 }
@@ -3049,6 +3667,46 @@ func (p *parser) callonS_MAX_AGE1() (interface{}, error) {
 	return p.cur.onS_MAX_AGE1(stack["t"])
 }
 
+func (c *current) onSTALE_WHILE_REVALIDATE1(t interface{}) (interface{}, error) {
+	return newStaleWhileRevalidate(t)
+}
+
+func (p *parser) callonSTALE_WHILE_REVALIDATE1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onSTALE_WHILE_REVALIDATE1(stack["t"])
+}
+
+func (c *current) onSTALE_IF_ERROR1(t interface{}) (interface{}, error) {
+	return newStaleIfError(t)
+}
+
+func (p *parser) callonSTALE_IF_ERROR1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onSTALE_IF_ERROR1(stack["t"])
+}
+
+func (c *current) onCACHE_FLAG1(f interface{}) (interface{}, error) {
+	return newCacheFlag(f)
+}
+
+func (p *parser) callonCACHE_FLAG1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onCACHE_FLAG1(stack["f"])
+}
+
+func (c *current) onCACHE_DIRECTIVE1() (interface{}, error) {
+	return stringify(c.text)
+}
+
+func (p *parser) callonCACHE_DIRECTIVE1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onCACHE_DIRECTIVE1()
+}
+
 func (c *current) onDEPENDS_ON1(t interface{}) (interface{}, error) {
 	return newDependsOn(t)
 }
@@ -3069,14 +3727,44 @@ func (p *parser) callonFLAGS_RULE1() (interface{}, error) {
 	return p.cur.onFLAGS_RULE1(stack["i"], stack["is"])
 }
 
-func (c *current) onIGNORE_FLAG1() (interface{}, error) {
-	return newIgnoreErrors()
+func (c *current) onIGNORE_FLAG1(codes interface{}) (interface{}, error) {
+	return newIgnoreErrors(codes)
 }
 
 func (p *parser) callonIGNORE_FLAG1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onIGNORE_FLAG1()
+	return p.cur.onIGNORE_FLAG1(stack["codes"])
+}
+
+func (c *current) onSUCCESS_ON1(codes interface{}) (interface{}, error) {
+	return newSuccessOn(codes)
+}
+
+func (p *parser) callonSUCCESS_ON1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onSUCCESS_ON1(stack["codes"])
+}
+
+func (c *current) onRAW_FLAG1() (interface{}, error) {
+	return newRaw()
+}
+
+func (p *parser) callonRAW_FLAG1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onRAW_FLAG1()
+}
+
+func (c *current) onSTATUS_CODES1(s, ss interface{}) (interface{}, error) {
+	return newStatusCodes(s, ss)
+}
+
+func (p *parser) callonSTATUS_CODES1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onSTATUS_CODES1(stack["s"], stack["ss"])
 }
 
 func (c *current) onCHAIN1(i, ii interface{}) (interface{}, error) {
@@ -31,6 +31,8 @@ func makeUse(queryAst *ast.Query) map[string]interface{} {
 		key := strings.Trim(use.Key, " ")
 		if use.Value.String != nil {
 			result[key] = *use.Value.String
+		} else if use.Value.Boolean != nil {
+			result[key] = *use.Value.Boolean
 		} else {
 			result[key] = *use.Value.Int
 		}
@@ -78,6 +80,10 @@ func makeStatement(block ast.Block) (domain.Statement, error) {
 			s.Timeout = makeTimeout(qualifier)
 		}
 
+		if qualifier.Hedge != nil {
+			s.Hedge = makeHedge(qualifier)
+		}
+
 		if qualifier.Headers != nil {
 			s.Headers = makeHeaders(qualifier)
 		}
@@ -92,12 +98,35 @@ func makeStatement(block ast.Block) (domain.Statement, error) {
 			s.CacheControl.SMaxAge = value
 		}
 
+		if qualifier.StaleWhileRevalidate != nil {
+			value := makeStaleWhileRevalidate(qualifier)
+			s.CacheControl.StaleWhileRevalidate = value
+		}
+
+		if qualifier.StaleIfError != nil {
+			value := makeStaleIfError(qualifier)
+			s.CacheControl.StaleIfError = value
+		}
+
+		if qualifier.CacheFlag != "" {
+			s.CacheControl = makeCacheFlag(s.CacheControl, qualifier.CacheFlag)
+		}
+
 		if qualifier.DependsOn != "" {
 			s.DependsOn = domain.DependsOn{Target: qualifier.DependsOn}
 		}
 
 		s.Hidden = qualifier.Hidden || s.Hidden
 		s.IgnoreErrors = qualifier.IgnoreErrors || s.IgnoreErrors
+		s.Raw = qualifier.Raw || s.Raw
+
+		if qualifier.IgnoreErrorsStatus != nil {
+			s.IgnoreErrorsStatus = append(s.IgnoreErrorsStatus, qualifier.IgnoreErrorsStatus...)
+		}
+
+		if qualifier.SuccessOn != nil {
+			s.SuccessOn = append(s.SuccessOn, qualifier.SuccessOn...)
+		}
 	}
 
 	return s, nil
@@ -149,6 +178,10 @@ func applyFunctions(v interface{}, functions []string) interface{} {
 			v = domain.NoExplode{Value: v}
 		case ast.AsQuery:
 			v = domain.AsQuery{Value: v}
+		case ast.AsForm:
+			v = domain.AsForm{Value: v}
+		case ast.AsMultipart:
+			v = domain.AsMultipart{Value: v}
 		}
 	}
 
@@ -274,6 +307,19 @@ func makeTimeout(qualifier ast.Qualifier) interface{} {
 	return nil
 }
 
+func makeHedge(qualifier ast.Qualifier) interface{} {
+	v := qualifier.Hedge
+	if v.Int != nil {
+		return *v.Int
+	}
+
+	if v.Variable != nil {
+		return domain.Variable{Target: *v.Variable}
+	}
+
+	return nil
+}
+
 func makeSMaxAge(qualifier ast.Qualifier) interface{} {
 	v := qualifier.SMaxAge
 	if v.Int != nil {
@@ -287,6 +333,49 @@ func makeSMaxAge(qualifier ast.Qualifier) interface{} {
 	return nil
 }
 
+func makeStaleWhileRevalidate(qualifier ast.Qualifier) interface{} {
+	v := qualifier.StaleWhileRevalidate
+	if v.Int != nil {
+		return *v.Int
+	}
+
+	if v.Variable != nil {
+		return domain.Variable{Target: *v.Variable}
+	}
+
+	return nil
+}
+
+func makeStaleIfError(qualifier ast.Qualifier) interface{} {
+	v := qualifier.StaleIfError
+	if v.Int != nil {
+		return *v.Int
+	}
+
+	if v.Variable != nil {
+		return domain.Variable{Target: *v.Variable}
+	}
+
+	return nil
+}
+
+func makeCacheFlag(cc domain.CacheControl, flag string) domain.CacheControl {
+	switch flag {
+	case ast.PrivateKeyword:
+		cc.Private = true
+	case ast.PublicKeyword:
+		cc.Public = true
+	case ast.NoStoreKeyword:
+		cc.NoStore = true
+	case ast.NoCacheKeyword:
+		cc.NoCache = true
+	case ast.MustRevalidateKeyword:
+		cc.MustRevalidate = true
+	}
+
+	return cc
+}
+
 func makeMaxAge(qualifier ast.Qualifier) interface{} {
 	v := qualifier.MaxAge
 	if v.Int != nil {
@@ -81,6 +81,21 @@ func TestQueryParser(t *testing.T) {
 			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", IgnoreErrors: true}}},
 			"from hero ignore-errors",
 		},
+		{
+			"Unique from statement and ignore errors flag for status codes",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", IgnoreErrors: true, IgnoreErrorsStatus: []int{404, 409}}}},
+			"from hero ignore-errors 404, 409",
+		},
+		{
+			"Unique from statement and success on flag",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", SuccessOn: []int{404}}}},
+			"from hero success-on 404",
+		},
+		{
+			"Unique from statement and raw flag",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", Raw: true}}},
+			"from hero raw",
+		},
 		{
 			"Unique from statement and fixed timeout",
 			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", Timeout: 2000}}},
@@ -91,6 +106,16 @@ func TestQueryParser(t *testing.T) {
 			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", Timeout: domain.Variable{"some-time"}}}},
 			"from hero timeout $some-time",
 		},
+		{
+			"Unique from statement and fixed hedge delay",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", Hedge: 50}}},
+			"from hero hedge after 50",
+		},
+		{
+			"Unique from statement and variable hedge delay",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", Hedge: domain.Variable{"delay"}}}},
+			"from hero hedge after $delay",
+		},
 		{
 			"Unique from statement and headers",
 			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", Headers: map[string]interface{}{"X-Trace-Id": "12345"}}}},
@@ -116,6 +141,25 @@ func TestQueryParser(t *testing.T) {
 			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", CacheControl: domain.CacheControl{MaxAge: domain.Variable{"maxAge"}, SMaxAge: domain.Variable{"sMaxAge"}}}}},
 			"from hero max-age $maxAge s-max-age $sMaxAge",
 		},
+		{
+			"Unique from statement and cache directives",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", CacheControl: domain.CacheControl{
+				MaxAge:               60,
+				StaleWhileRevalidate: 30,
+				StaleIfError:         domain.Variable{"staleIfError"},
+				Private:              true,
+				MustRevalidate:       true,
+			}}}},
+			"from hero max-age 60 stale-while-revalidate 30 stale-if-error $staleIfError private must-revalidate",
+		},
+		{
+			"Unique from statement with cache directives use modifiers",
+			domain.Query{
+				Use:        domain.Modifiers{"no-cache": true, "stale-if-error": 600},
+				Statements: []domain.Statement{{Method: "from", Resource: "hero"}},
+			},
+			"use no-cache\nuse stale-if-error 600\nfrom hero",
+		},
 		{
 			"Unique from statement and flattened list parameters",
 			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", With: domain.Params{Values: map[string]interface{}{"id": domain.NoMultiplex{[]interface{}{1, 2}}}}}}},
@@ -141,6 +185,16 @@ func TestQueryParser(t *testing.T) {
 			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", With: domain.Params{Values: map[string]interface{}{"id": domain.AsBody{Value: []interface{}{map[string]interface{}{"registryNumber": "abdcef12345"}}}}}}}},
 			`from hero with id = [{"registryNumber": "abdcef12345"}] -> as-body`,
 		},
+		{
+			"Unique to statement and parameter defined as form",
+			domain.Query{Statements: []domain.Statement{{Method: "to", Resource: "hero", With: domain.Params{Values: map[string]interface{}{"hero": domain.AsForm{Value: map[string]interface{}{"name": "batman"}}}}}}},
+			`to hero with hero = {"name": "batman"} -> as-form`,
+		},
+		{
+			"Unique to statement and body defined as multipart form",
+			domain.Query{Statements: []domain.Statement{{Method: "to", Resource: "hero", With: domain.Params{Body: domain.AsMultipart{Value: domain.Variable{Target: "hero"}}, Values: map[string]interface{}{}}}}},
+			`to hero with $hero -> as-multipart`,
+		},
 		{
 			"Unique from statement and parameter flattened",
 			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", With: domain.Params{Values: map[string]interface{}{"id": domain.Flatten{[]interface{}{[]interface{}{1}, []interface{}{2}, []interface{}{3}}}}}}}},
@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+
+	"github.com/bluele/gcache"
+)
+
+type responseItem struct {
+	value    interface{}
+	storedAt time.Time
+}
+
+// ResponseCache is an in-memory container that uses a LRU
+// eviction strategy to store query responses.
+// Unlike Cache, each entry has its own time to live
+// and is discarded once it is due, never being refreshed.
+type ResponseCache struct {
+	log    restql.Logger
+	gcache gcache.Cache
+}
+
+// NewResponseCache constructs a ResponseCache instance.
+func NewResponseCache(log restql.Logger, size int) *ResponseCache {
+	c := gcache.New(size).LRU().Build()
+
+	return &ResponseCache{log: log, gcache: c}
+}
+
+// Get retrieves the entry for the given key alongside the
+// time elapsed since it was stored.
+func (c *ResponseCache) Get(key string) (value interface{}, age time.Duration, found bool) {
+	obj, err := c.gcache.GetIFPresent(key)
+	if err != nil {
+		return nil, 0, false
+	}
+
+	item, ok := obj.(responseItem)
+	if !ok {
+		c.log.Info("failed to convert response cache content", "key", key)
+		return nil, 0, false
+	}
+
+	return item.value, time.Since(item.storedAt), true
+}
+
+// Set stores an entry for the given key that will expire
+// after the given time to live.
+func (c *ResponseCache) Set(key string, value interface{}, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	item := responseItem{value: value, storedAt: time.Now()}
+
+	err := c.gcache.SetWithExpire(key, item, ttl)
+	if err != nil {
+		c.log.Error("failed to set value on response cache", err, "key", key)
+	}
+}
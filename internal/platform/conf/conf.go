@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"github.com/caarlos0/env/v6"
 	"gopkg.in/yaml.v2"
 )
@@ -39,6 +40,51 @@ type requestCancellationConf struct {
 	WatchInterval time.Duration `yaml:"watchInterval"`
 }
 
+type rateLimitConf struct {
+	Host     string        `yaml:"host"`
+	Mapping  string        `yaml:"mapping"`
+	Tenant   string        `yaml:"tenant"`
+	Rate     float64       `yaml:"rate"`
+	Burst    int           `yaml:"burst"`
+	Strategy string        `yaml:"strategy"`
+	MaxWait  time.Duration `yaml:"maxWait"`
+}
+
+type concurrencyQuotaConf struct {
+	MaxConcurrentQueries    int `yaml:"maxConcurrentQueries"`
+	MaxConcurrentGoroutines int `yaml:"maxConcurrentGoroutines"`
+}
+
+type statusDefaultsConf struct {
+	IgnoreErrors []int `yaml:"ignoreErrors"`
+	SuccessOn    []int `yaml:"successOn"`
+}
+
+// mappingConf is a mapping value on the configuration file,
+// that can be either a plain URL or an extended definition.
+type mappingConf string
+
+func (m *mappingConf) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var url string
+	if err := unmarshal(&url); err == nil {
+		*m = mappingConf(url)
+		return nil
+	}
+
+	var definition restql.MappingDefinition
+	if err := unmarshal(&definition); err != nil {
+		return err
+	}
+
+	value, err := definition.Encode()
+	if err != nil {
+		return err
+	}
+
+	*m = mappingConf(value)
+	return nil
+}
+
 type tenantByHostConf struct {
 	Enable        bool              `yaml:"enable" env:"RESTQL_TENANT_BY_HOST_ENABLED"`
 	DefaultTenant string            `yaml:"defaultTenant" env:"RESTQL_TENANT_BY_HOST_DEFAULT_TENANT"`
@@ -52,6 +98,33 @@ type Config struct {
 		QueryResourceTimeout time.Duration `env:"RESTQL_QUERY_RESOURCE_TIMEOUT" envDefault:"5s"`
 
 		GlobalQueryTimeout time.Duration `env:"RESTQL_QUERY_GLOBAL_TIMEOUT" envDefault:"30s"`
+		FailFast           bool          `yaml:"failFast" env:"RESTQL_FAIL_FAST"`
+
+		DeadlinePropagation struct {
+			Header string `yaml:"header" env:"RESTQL_DEADLINE_HEADER"`
+			Format string `yaml:"format" env:"RESTQL_DEADLINE_HEADER_FORMAT"`
+		} `yaml:"deadlinePropagation"`
+
+		Hedging map[string]time.Duration `yaml:"hedging"`
+
+		LoadBalancing struct {
+			Strategy  string            `yaml:"strategy" env:"RESTQL_LOAD_BALANCING_STRATEGY"`
+			Resources map[string]string `yaml:"resources"`
+			Ejection  struct {
+				ConsecutiveFailures int           `yaml:"consecutiveFailures" env:"RESTQL_LOAD_BALANCING_EJECTION_FAILURES"`
+				Duration            time.Duration `yaml:"duration" env:"RESTQL_LOAD_BALANCING_EJECTION_DURATION"`
+			} `yaml:"ejection"`
+		} `yaml:"loadBalancing"`
+
+		ErrorKindStatus map[string]int `yaml:"errorKindStatus"`
+
+		StatusDefaults map[string]statusDefaultsConf `yaml:"statusDefaults"`
+
+		StatusStrategy struct {
+			Default    string            `yaml:"default" env:"RESTQL_STATUS_STRATEGY"`
+			Namespaces map[string]string `yaml:"namespaces"`
+			Queries    map[string]string `yaml:"queries"`
+		} `yaml:"statusStrategy"`
 
 		Server struct {
 			APIAddr         string `env:"RESTQL_PORT,required"`
@@ -64,6 +137,8 @@ type Config struct {
 				AuthorizationCode string `yaml:"authorizationCode" env:"RESTQL_ADMIN_AUTHORIZATION_CODE"`
 			} `yaml:"admin"`
 
+			ProblemDetails bool `yaml:"problemDetails" env:"RESTQL_PROBLEM_DETAILS"`
+
 			GracefulShutdownTimeout time.Duration `yaml:"gracefulShutdownTimeout"`
 			ReadTimeout             time.Duration `yaml:"readTimeout"`
 			IdleTimeout             time.Duration `yaml:"idleTimeout"`
@@ -80,6 +155,21 @@ type Config struct {
 		Client struct {
 			MaxConcurrentQueries    int `yaml:"maxConcurrentQueries" env:"RESTQL_MAX_CONCURRENT_QUERIES"`
 			MaxConcurrentGoroutines int `yaml:"maxConcurrentGoroutines" env:"RESTQL_MAX_CONCURRENT_GOROUTINES"`
+			WorkerPoolSize          int `yaml:"workerPoolSize" env:"RESTQL_WORKER_POOL_SIZE"`
+
+			Queue struct {
+				MaxWait   time.Duration `yaml:"maxWait" env:"RESTQL_QUERY_QUEUE_MAX_WAIT"`
+				MaxLength int           `yaml:"maxLength" env:"RESTQL_QUERY_QUEUE_MAX_LENGTH"`
+			} `yaml:"queue"`
+
+			AdaptiveConcurrency struct {
+				Enable           bool          `yaml:"enable" env:"RESTQL_ADAPTIVE_CONCURRENCY_ENABLE"`
+				InitialLimit     int           `yaml:"initialLimit" env:"RESTQL_ADAPTIVE_CONCURRENCY_INITIAL_LIMIT"`
+				MinLimit         int           `yaml:"minLimit" env:"RESTQL_ADAPTIVE_CONCURRENCY_MIN_LIMIT"`
+				MaxLimit         int           `yaml:"maxLimit" env:"RESTQL_ADAPTIVE_CONCURRENCY_MAX_LIMIT"`
+				LatencyThreshold time.Duration `yaml:"latencyThreshold" env:"RESTQL_ADAPTIVE_CONCURRENCY_LATENCY_THRESHOLD"`
+				BackoffRatio     float64       `yaml:"backoffRatio"`
+			} `yaml:"adaptiveConcurrency"`
 
 			DnsRefreshInterval  time.Duration `yaml:"dnsRefreshInterval"`
 			ConnTimeout         time.Duration `yaml:"connectionTimeout"`
@@ -88,6 +178,16 @@ type Config struct {
 			MaxIdleConns        int           `yaml:"maxIdleConnections"`
 			MaxIdleConnsPerHost int           `yaml:"maxIdleConnectionsPerHost"`
 			MaxIdleConnDuration time.Duration `yaml:"maxIdleConnectionDuration"`
+
+			Compression             bool `yaml:"compression" env:"RESTQL_HTTP_CLIENT_COMPRESSION"`
+			MaxDecompressedBodySize int  `yaml:"maxDecompressedBodySize" env:"RESTQL_HTTP_CLIENT_MAX_DECOMPRESSED_BODY_SIZE"`
+
+			Quotas struct {
+				Tenants    map[string]concurrencyQuotaConf `yaml:"tenants"`
+				Namespaces map[string]concurrencyQuotaConf `yaml:"namespaces"`
+			} `yaml:"quotas"`
+
+			RateLimits []rateLimitConf `yaml:"rateLimits"`
 		} `yaml:"client"`
 	} `yaml:"http"`
 
@@ -119,6 +219,18 @@ type Config struct {
 		Parser struct {
 			MaxSize int `yaml:"maxSize" env:"RESTQL_CACHE_PARSER_MAX_SIZE"`
 		} `yaml:"parser"`
+		Response struct {
+			Enable     bool     `yaml:"enable" env:"RESTQL_CACHE_RESPONSE_ENABLE"`
+			MaxSize    int      `yaml:"maxSize" env:"RESTQL_CACHE_RESPONSE_MAX_SIZE"`
+			KeyParams  []string `yaml:"keyParams" env:"RESTQL_CACHE_RESPONSE_KEY_PARAMS"`
+			KeyHeaders []string `yaml:"keyHeaders" env:"RESTQL_CACHE_RESPONSE_KEY_HEADERS"`
+		} `yaml:"response"`
+		SurrogateKey struct {
+			Enable     bool   `yaml:"enable" env:"RESTQL_CACHE_SURROGATE_KEY_ENABLE"`
+			Header     string `yaml:"header" env:"RESTQL_CACHE_SURROGATE_KEY_HEADER"`
+			Separator  string `yaml:"separator" env:"RESTQL_CACHE_SURROGATE_KEY_SEPARATOR"`
+			IncludeIDs bool   `yaml:"includeIds" env:"RESTQL_CACHE_SURROGATE_KEY_INCLUDE_IDS"`
+		} `yaml:"surrogateKey"`
 	} `yaml:"cache"`
 
 	Plugins struct {
@@ -127,7 +239,7 @@ type Config struct {
 
 	Tenant string `env:"RESTQL_TENANT"`
 
-	TenantMappings map[string]map[string]string `yaml:"tenants"`
+	TenantMappings map[string]map[string]mappingConf `yaml:"tenants"`
 
 	Queries map[string]map[string][]string `yaml:"queries"`
 
@@ -22,6 +22,13 @@ http:
     writeTimeout: 1s
     maxIdleConnectionsPerHost: 512
     maxIdleConnectionDuration: 10s
+    maxDecompressedBodySize: 10485760
+
+  loadBalancing:
+    strategy: round-robin
+    ejection:
+      consecutiveFailures: 5
+      duration: 30s
 
 debugging:
   queryParam: true
@@ -39,6 +46,11 @@ cache:
     maxSize: 100
   parser:
     maxSize: 100
+  response:
+    maxSize: 1000
+  surrogateKey:
+    header: Surrogate-Key
+    separator: " "
 
 database:
   timeout: 1000
@@ -0,0 +1,139 @@
+package httpclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Media types of the request bodies encoded as forms.
+const (
+	formContentType      = "application/x-www-form-urlencoded"
+	multipartContentType = "multipart/form-data"
+)
+
+// marshalBody encodes the request body according to its content type,
+// using JSON when it is not a form. It also returns the content type
+// to be sent, since multipart bodies must define their boundary.
+func marshalBody(body interface{}, contentType string) ([]byte, string, error) {
+	if strBody, ok := body.(string); ok {
+		return []byte(strBody), contentType, nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch mediaType {
+	case formContentType:
+		values, err := makeFormValues(body)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return []byte(values.Encode()), contentType, nil
+	case multipartContentType:
+		values, err := makeFormValues(body)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return makeMultipartBody(values)
+	default:
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to marshal request body")
+		}
+
+		return data, contentType, nil
+	}
+}
+
+// makeFormValues flattens the body into form fields. Lists of primitive
+// values are sent as repeated fields, while nested objects and lists of
+// objects use the bracket notation, as in `hero[name]` and `heroes[0][name]`.
+func makeFormValues(body interface{}) (url.Values, error) {
+	m, ok := body.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("failed to encode request body as form: expected an object but got %T", body)
+	}
+
+	values := make(url.Values)
+	for key, value := range m {
+		appendFormValue(values, key, value)
+	}
+
+	return values, nil
+}
+
+func appendFormValue(values url.Values, key string, value interface{}) {
+	switch value := value.(type) {
+	case nil:
+		return
+	case map[string]interface{}:
+		for k, v := range value {
+			appendFormValue(values, key+"["+k+"]", v)
+		}
+	case []interface{}:
+		for i, v := range value {
+			switch v.(type) {
+			case map[string]interface{}, []interface{}:
+				appendFormValue(values, key+"["+strconv.Itoa(i)+"]", v)
+			default:
+				appendFormValue(values, key, v)
+			}
+		}
+	case string:
+		values.Add(key, value)
+	case bool:
+		values.Add(key, strconv.FormatBool(value))
+	case int:
+		values.Add(key, strconv.Itoa(value))
+	case float64:
+		values.Add(key, strconv.FormatFloat(value, 'f', -1, 64))
+	default:
+		values.Add(key, fmt.Sprint(value))
+	}
+}
+
+func makeMultipartBody(values url.Values) ([]byte, string, error) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	for _, key := range keys {
+		for _, value := range values[key] {
+			if err := writer.WriteField(key, value); err != nil {
+				return nil, "", errors.Wrap(err, "failed to encode request body as multipart form")
+			}
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, "", errors.Wrap(err, "failed to encode request body as multipart form")
+	}
+
+	return buf.Bytes(), writer.FormDataContentType(), nil
+}
+
+// contentTypeHeader finds the request content type,
+// since the header name may not be canonical.
+func contentTypeHeader(headers map[string]string) (string, string) {
+	for key, value := range headers {
+		if strings.EqualFold(key, "Content-Type") {
+			return key, value
+		}
+	}
+
+	return "Content-Type", ""
+}
@@ -0,0 +1,81 @@
+package httpclient
+
+import (
+	"mime"
+	"mime/multipart"
+	"strings"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestMarshalBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        interface{}
+		contentType string
+		expected    string
+	}{
+		{"json body", map[string]interface{}{"name": "batman"}, "application/json", `{"name":"batman"}`},
+		{"string body", "name=batman", "application/x-www-form-urlencoded", "name=batman"},
+		{
+			"form body with primitive values",
+			map[string]interface{}{"name": "batman", "age": 42, "height": 1.88, "active": true, "nickname": nil},
+			"application/x-www-form-urlencoded",
+			"active=true&age=42&height=1.88&name=batman",
+		},
+		{
+			"form body with lists as repeated fields",
+			map[string]interface{}{"ids": []interface{}{"1", "2", "3"}},
+			"application/x-www-form-urlencoded; charset=utf-8",
+			"ids=1&ids=2&ids=3",
+		},
+		{
+			"form body with nested objects",
+			map[string]interface{}{
+				"hero":      map[string]interface{}{"name": "batman", "city": map[string]interface{}{"name": "gotham"}},
+				"sidekicks": []interface{}{map[string]interface{}{"name": "robin"}, map[string]interface{}{"name": "batgirl"}},
+			},
+			"application/x-www-form-urlencoded",
+			"hero%5Bcity%5D%5Bname%5D=gotham&hero%5Bname%5D=batman&sidekicks%5B0%5D%5Bname%5D=robin&sidekicks%5B1%5D%5Bname%5D=batgirl",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, contentType, err := marshalBody(tt.body, tt.contentType)
+			test.VerifyError(t, err)
+
+			test.Equal(t, string(data), tt.expected)
+			test.Equal(t, contentType, tt.contentType)
+		})
+	}
+}
+
+func TestMarshalBodyAsMultipart(t *testing.T) {
+	body := map[string]interface{}{
+		"name": "batman",
+		"ids":  []interface{}{1, 2},
+		"city": map[string]interface{}{"name": "gotham"},
+	}
+
+	data, contentType, err := marshalBody(body, "multipart/form-data")
+	test.VerifyError(t, err)
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	test.VerifyError(t, err)
+	test.Equal(t, mediaType, "multipart/form-data")
+
+	form, err := multipart.NewReader(strings.NewReader(string(data)), params["boundary"]).ReadForm(1024)
+	test.VerifyError(t, err)
+
+	expected := map[string][]string{"name": {"batman"}, "ids": {"1", "2"}, "city[name]": {"gotham"}}
+	test.Equal(t, form.Value, expected)
+}
+
+func TestMarshalBodyAsFormFailsForNonObject(t *testing.T) {
+	_, _, err := marshalBody([]interface{}{"1"}, "application/x-www-form-urlencoded")
+	if err == nil {
+		t.Fatal("expected an error when encoding a list as form")
+	}
+}
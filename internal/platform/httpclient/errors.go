@@ -0,0 +1,48 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"strings"
+	"syscall"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/pkg/errors"
+	"github.com/valyala/fasthttp"
+)
+
+// requestErrorKind identifies the kind of failure
+// from the error returned by fasthttp.
+func requestErrorKind(err error) string {
+	var dnsErr *net.DNSError
+	var recordHeaderErr tls.RecordHeaderError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var certificateInvalidErr x509.CertificateInvalidError
+
+	switch {
+	case err == fasthttp.ErrTimeout || err == fasthttp.ErrDialTimeout || err == fasthttp.ErrTLSHandshakeTimeout:
+		return domain.ErrorKindTimeout
+	case errors.As(err, &dnsErr):
+		return domain.ErrorKindDNS
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return domain.ErrorKindConnectionRefused
+	case errors.Is(err, syscall.ECONNRESET):
+		return domain.ErrorKindConnectionReset
+	case errors.As(err, &recordHeaderErr),
+		errors.As(err, &unknownAuthorityErr),
+		errors.As(err, &hostnameErr),
+		errors.As(err, &certificateInvalidErr),
+		isTLSErrorMessage(err.Error()):
+		return domain.ErrorKindTLS
+	default:
+		return domain.ErrorKindUnknown
+	}
+}
+
+// isTLSErrorMessage catches the TLS failures
+// that are returned without a specific type.
+func isTLSErrorMessage(msg string) bool {
+	return strings.Contains(msg, "tls: ") || strings.Contains(msg, "x509: ")
+}
@@ -0,0 +1,46 @@
+package httpclient
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/valyala/fasthttp"
+)
+
+func TestRequestErrorKind(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"timeout", fasthttp.ErrTimeout, domain.ErrorKindTimeout},
+		{"dial timeout", fasthttp.ErrDialTimeout, domain.ErrorKindTimeout},
+		{"dns failure", &net.DNSError{Err: "no such host", Name: "hero.io", IsNotFound: true}, domain.ErrorKindDNS},
+		{
+			"connection refused",
+			&net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)},
+			domain.ErrorKindConnectionRefused,
+		},
+		{
+			"connection reset",
+			fmt.Errorf("read failed: %w", &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}),
+			domain.ErrorKindConnectionReset,
+		},
+		{"unknown certificate authority", x509.UnknownAuthorityError{}, domain.ErrorKindTLS},
+		{"tls alert", errors.New("remote error: tls: handshake failure"), domain.ErrorKindTLS},
+		{"unknown", errors.New("some failure"), domain.ErrorKindUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.Equal(t, requestErrorKind(tt.err), tt.expected)
+		})
+	}
+}
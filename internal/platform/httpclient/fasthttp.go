@@ -29,6 +29,9 @@ type fastHTTPClient struct {
 	log          restql.Logger
 	lifecycle    plugins.Lifecycle
 	responsePool *sync.Pool
+	rateLimiter  *rateLimiter
+
+	maxDecompressedBodySize int
 }
 
 func newFastHTTPClient(log restql.Logger, pm plugins.Lifecycle, cfg *conf.Config) *fastHTTPClient {
@@ -83,13 +86,35 @@ func newFastHTTPClient(log restql.Logger, pm plugins.Lifecycle, cfg *conf.Config
 		MaxConnWaitTimeout:            clientCfg.ConnTimeout,
 	}
 
-	return &fastHTTPClient{client: c, log: log, lifecycle: pm, responsePool: rp}
+	rl := newRateLimiter(log, cfg, time.Now)
+
+	return &fastHTTPClient{
+		client:       c,
+		log:          log,
+		lifecycle:    pm,
+		responsePool: rp,
+		rateLimiter:  rl,
+
+		maxDecompressedBodySize: clientCfg.MaxDecompressedBodySize,
+	}
 }
 
 func (hc *fastHTTPClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
 	requestCtx := hc.lifecycle.BeforeRequest(ctx, request)
 
+	err := hc.rateLimiter.Wait(ctx, request.Host)
+	if err != nil {
+		target := fmt.Sprintf("%s://%s%s", request.Schema, request.Host, request.Path)
+		hc.log.Info("request rate limited", "url", target, "method", request.Method)
+		response := makeErrorResponse(target, 0, fasthttp.StatusTooManyRequests)
+
+		hc.lifecycle.AfterRequest(requestCtx, request, response, err)
+
+		return response, err
+	}
+
 	c := hc.responsePool.Get().(chan httpResult)
+	start := time.Now()
 
 	go func() {
 		req := fasthttp.AcquireRequest()
@@ -113,10 +138,32 @@ func (hc *fastHTTPClient) Do(ctx context.Context, request restql.HTTPRequest) (r
 		c <- httpResult{target: reqUri, err: err, duration: finish, response: res}
 	}()
 
-	hr := <-c
-	hc.responsePool.Put(c)
+	var hr httpResult
+	select {
+	case hr = <-c:
+		hc.responsePool.Put(c)
+	case <-ctx.Done():
+		go hc.discard(c)
+		target := fmt.Sprintf("%s://%s%s", request.Schema, request.Host, request.Path)
+		hr = httpResult{target: target, err: ctx.Err(), duration: time.Since(start)}
+	}
 
 	switch {
+	case hr.err == context.DeadlineExceeded:
+		hc.log.Info("request deadline exceeded", "url", hr.target, "method", request.Method, "duration-ms", hr.duration.Milliseconds())
+		response := makeErrorResponse(hr.target, hr.duration, fasthttp.StatusRequestTimeout)
+
+		err := fmt.Errorf("%w: %s", hr.err, domain.ErrRequestTimeout)
+		hc.lifecycle.AfterRequest(requestCtx, request, response, err)
+
+		return response, domain.ErrRequestTimeout
+	case hr.err == context.Canceled:
+		hc.log.Debug("request cancelled", "url", hr.target, "method", request.Method, "duration-ms", hr.duration.Milliseconds())
+		response := makeErrorResponse(hr.target, hr.duration, 0)
+
+		hc.lifecycle.AfterRequest(requestCtx, request, response, hr.err)
+
+		return response, domain.NewRequestError(domain.ErrorKindCancelled, errors.Wrap(hr.err, "request cancelled"))
 	case hr.err == fasthttp.ErrTimeout || hr.err == fasthttp.ErrDialTimeout || hr.err == fasthttp.ErrTLSHandshakeTimeout:
 		hc.log.Info("request timed out", "url", hr.target, "method", request.Method, "duration-ms", hr.duration.Milliseconds())
 		response := makeErrorResponse(hr.target, hr.duration, fasthttp.StatusRequestTimeout)
@@ -128,15 +175,32 @@ func (hc *fastHTTPClient) Do(ctx context.Context, request restql.HTTPRequest) (r
 
 		return response, domain.ErrRequestTimeout
 	case hr.err != nil:
-		response := makeErrorResponse(hr.target, hr.duration, hr.response.StatusCode())
-
+		statusCode := 0
 		if hr.response != nil {
+			statusCode = hr.response.StatusCode()
 			fasthttp.ReleaseResponse(hr.response)
 		}
 
+		response := makeErrorResponse(hr.target, hr.duration, statusCode)
+
 		hc.lifecycle.AfterRequest(requestCtx, request, response, hr.err)
 
-		return response, errors.Wrap(hr.err, "request execution failed")
+		kind := requestErrorKind(hr.err)
+		hc.log.Debug("request failed", "url", hr.target, "method", request.Method, "error-kind", kind)
+
+		return response, domain.NewRequestError(kind, errors.Wrap(hr.err, "request execution failed"))
+	}
+
+	compressedSize, err := decompressBody(hr.response, hc.maxDecompressedBodySize)
+	if err != nil {
+		hc.log.Error("failed to decompress response body", err, "url", hr.target, "statusCode", hr.response.StatusCode())
+		response := makeErrorResponse(hr.target, hr.duration, fasthttp.StatusBadGateway)
+
+		fasthttp.ReleaseResponse(hr.response)
+
+		hc.lifecycle.AfterRequest(requestCtx, request, response, err)
+
+		return response, domain.NewRequestError(domain.ErrorKindDecompression, err)
 	}
 
 	body, err := unmarshalBody(hc.log, hr.response)
@@ -150,6 +214,9 @@ func (hc *fastHTTPClient) Do(ctx context.Context, request restql.HTTPRequest) (r
 		Headers:    readHeaders(hr.response),
 		Duration:   hr.duration,
 		Body:       body,
+
+		Size:           len(body.Bytes()),
+		CompressedSize: compressedSize,
 	}
 
 	fasthttp.ReleaseResponse(hr.response)
@@ -158,3 +225,13 @@ func (hc *fastHTTPClient) Do(ctx context.Context, request restql.HTTPRequest) (r
 
 	return response, nil
 }
+
+// discard waits for the result of a cancelled request
+// to release it and return the channel to the pool.
+func (hc *fastHTTPClient) discard(c chan httpResult) {
+	hr := <-c
+	if hr.response != nil {
+		fasthttp.ReleaseResponse(hr.response)
+	}
+	hc.responsePool.Put(c)
+}
@@ -0,0 +1,67 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestFastHTTPClientFailsOnInvalidFormBody(t *testing.T) {
+	cfg := &conf.Config{}
+	cfg.HTTP.Client.DnsRefreshInterval = time.Hour
+
+	client := newFastHTTPClient(test.NoOpLogger, plugins.NoOpLifecycle, cfg)
+
+	request := restql.HTTPRequest{
+		Method:  "POST",
+		Schema:  "http",
+		Host:    "hero.io",
+		Path:    "/hero",
+		Body:    []interface{}{"batman"},
+		Headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+		Timeout: time.Second,
+	}
+
+	response, err := client.Do(context.Background(), request)
+	if err == nil {
+		t.Fatal("expected an error when the body cannot be encoded as form")
+	}
+
+	test.Equal(t, response.StatusCode, 0)
+}
+
+func TestFastHTTPClientFailsOnInvalidCompressedBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name": "batman"}`))
+	}))
+	defer server.Close()
+
+	cfg := &conf.Config{}
+	cfg.HTTP.Client.DnsRefreshInterval = time.Hour
+
+	client := newFastHTTPClient(test.NoOpLogger, plugins.NoOpLifecycle, cfg)
+
+	request := restql.HTTPRequest{
+		Method:  "GET",
+		Schema:  "http",
+		Host:    strings.TrimPrefix(server.URL, "http://"),
+		Path:    "/hero",
+		Timeout: time.Second,
+	}
+
+	response, err := client.Do(context.Background(), request)
+
+	test.Equal(t, domain.ErrorKindOf(err), domain.ErrorKindDecompression)
+	test.Equal(t, response.StatusCode, 502)
+}
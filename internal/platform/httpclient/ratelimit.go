@@ -0,0 +1,198 @@
+package httpclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+)
+
+// Strategies available when a rate limit is reached.
+const (
+	rateLimitWait     = "wait"
+	rateLimitFailFast = "fail-fast"
+)
+
+// tokenBucket is a rate limiter that allows bursts of up to
+// burst calls and refills tokens at the given rate per second.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newTokenBucket(rate float64, burst int, now func() time.Time) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: now(), now: now}
+}
+
+// reserve takes a token from the bucket and returns how long the
+// caller must wait before using it. If the token will not be available
+// within maxWait, no token is taken and false is returned.
+func (b *tokenBucket) reserve(maxWait time.Duration) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	elapsed := now.Sub(b.last).Seconds()
+	b.last = now
+
+	b.tokens += elapsed * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, true
+	}
+
+	wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	if wait > maxWait {
+		return 0, false
+	}
+
+	b.tokens--
+	return wait, true
+}
+
+// release gives back a token reserved but not used.
+func (b *tokenBucket) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+type rateLimitRule struct {
+	host     string
+	mapping  string
+	tenant   string
+	strategy string
+	maxWait  time.Duration
+	bucket   *tokenBucket
+}
+
+// rateLimiter enforces the upstream rate limits defined by host or mapping,
+// optionally restricted to a tenant. When more than one rule matches a call
+// the most specific is used: mapping over host and tenant specific over
+// tenant agnostic.
+type rateLimiter struct {
+	log   restql.Logger
+	rules []rateLimitRule
+}
+
+func newRateLimiter(log restql.Logger, cfg *conf.Config, now func() time.Time) *rateLimiter {
+	var rules []rateLimitRule
+	for _, rl := range cfg.HTTP.Client.RateLimits {
+		if rl.Rate <= 0 || (rl.Host == "" && rl.Mapping == "") {
+			log.Warn("ignoring invalid rate limit", "host", rl.Host, "mapping", rl.Mapping, "tenant", rl.Tenant, "rate", rl.Rate)
+			continue
+		}
+
+		strategy := rl.Strategy
+		if strategy != rateLimitWait {
+			strategy = rateLimitFailFast
+		}
+
+		rules = append(rules, rateLimitRule{
+			host:     rl.Host,
+			mapping:  rl.Mapping,
+			tenant:   rl.Tenant,
+			strategy: strategy,
+			maxWait:  rl.MaxWait,
+			bucket:   newTokenBucket(rl.Rate, rl.Burst, now),
+		})
+	}
+
+	return &rateLimiter{log: log, rules: rules}
+}
+
+// Wait blocks until the call to the given host is allowed by its rate limit.
+// It returns domain.ErrRateLimited when the call is denied, either because
+// the strategy is to fail fast or because the time it would wait exceeds the
+// rule budget or the context deadline.
+func (rl *rateLimiter) Wait(ctx context.Context, host string) error {
+	if len(rl.rules) == 0 {
+		return nil
+	}
+
+	target, _ := domain.GetRequestTarget(ctx)
+
+	rule, found := rl.findRule(host, target)
+	if !found {
+		return nil
+	}
+
+	budget := time.Duration(0)
+	if rule.strategy == rateLimitWait {
+		budget = rule.maxWait
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < budget {
+			budget = time.Until(deadline)
+		}
+	}
+
+	wait, ok := rule.bucket.reserve(budget)
+	if !ok {
+		return domain.ErrRateLimited
+	}
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		rule.bucket.release()
+		return domain.ErrRateLimited
+	}
+}
+
+func (rl *rateLimiter) findRule(host string, target domain.RequestTarget) (rateLimitRule, bool) {
+	bestScore := -1
+	var best rateLimitRule
+
+	for _, r := range rl.rules {
+		score := 0
+
+		switch {
+		case r.mapping != "" && r.mapping == target.Resource:
+			score += 2
+		case r.mapping == "" && r.host == host:
+		default:
+			continue
+		}
+
+		switch {
+		case r.tenant == "":
+		case r.tenant == target.Tenant:
+			score++
+		default:
+			continue
+		}
+
+		if score > bestScore {
+			bestScore = score
+			best = r
+		}
+	}
+
+	return best, bestScore >= 0
+}
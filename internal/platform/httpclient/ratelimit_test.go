@@ -0,0 +1,132 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"gopkg.in/yaml.v2"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestTokenBucket(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	bucket := newTokenBucket(10, 2, clock.Now)
+
+	wait, ok := bucket.reserve(0)
+	test.Equal(t, ok, true)
+	test.Equal(t, wait, time.Duration(0))
+
+	wait, ok = bucket.reserve(0)
+	test.Equal(t, ok, true)
+	test.Equal(t, wait, time.Duration(0))
+
+	_, ok = bucket.reserve(0)
+	test.Equal(t, ok, false)
+
+	wait, ok = bucket.reserve(200 * time.Millisecond)
+	test.Equal(t, ok, true)
+	test.Equal(t, wait, 100*time.Millisecond)
+
+	clock.Advance(300 * time.Millisecond)
+
+	wait, ok = bucket.reserve(0)
+	test.Equal(t, ok, true)
+	test.Equal(t, wait, time.Duration(0))
+}
+
+func TestRateLimiterRuleSelection(t *testing.T) {
+	cfg := rateLimitConfig(t, `
+http:
+  client:
+    rateLimits:
+      - host: hero.io
+        rate: 1
+      - mapping: hero
+        rate: 1
+      - mapping: hero
+        tenant: dc
+        rate: 1
+      - host: sidekick.io
+        tenant: dc
+        rate: 1
+`)
+
+	rl := newRateLimiter(test.NoOpLogger, cfg, time.Now)
+
+	tests := []struct {
+		name     string
+		host     string
+		target   domain.RequestTarget
+		expected int
+		found    bool
+	}{
+		{"should select host rule", "hero.io", domain.RequestTarget{Resource: "villain"}, 0, true},
+		{"should prefer mapping rule over host rule", "hero.io", domain.RequestTarget{Resource: "hero"}, 1, true},
+		{"should prefer tenant specific rule", "hero.io", domain.RequestTarget{Resource: "hero", Tenant: "dc"}, 2, true},
+		{"should skip rule for other tenant", "sidekick.io", domain.RequestTarget{Resource: "sidekick", Tenant: "marvel"}, 0, false},
+		{"should select tenant host rule", "sidekick.io", domain.RequestTarget{Resource: "sidekick", Tenant: "dc"}, 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := rl.findRule(tt.host, tt.target)
+			test.Equal(t, found, tt.found)
+			if tt.found {
+				test.Equal(t, got.bucket == rl.rules[tt.expected].bucket, true)
+			}
+		})
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	cfg := rateLimitConfig(t, `
+http:
+  client:
+    rateLimits:
+      - host: hero.io
+        rate: 1
+        strategy: fail-fast
+      - host: sidekick.io
+        rate: 100
+        strategy: wait
+        maxWait: 1s
+`)
+
+	rl := newRateLimiter(test.NoOpLogger, cfg, time.Now)
+	ctx := context.Background()
+
+	test.Equal(t, rl.Wait(ctx, "hero.io"), nil)
+	test.Equal(t, errors.Is(rl.Wait(ctx, "hero.io"), domain.ErrRateLimited), true)
+
+	test.Equal(t, rl.Wait(ctx, "sidekick.io"), nil)
+	test.Equal(t, rl.Wait(ctx, "sidekick.io"), nil)
+
+	test.Equal(t, rl.Wait(ctx, "villain.io"), nil)
+}
+
+func rateLimitConfig(t *testing.T, data string) *conf.Config {
+	cfg := &conf.Config{}
+
+	err := yaml.Unmarshal([]byte(data), cfg)
+	if err != nil {
+		t.Fatalf("failed to unmarshal config : %v", err)
+	}
+
+	return cfg
+}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/json"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
-	"github.com/pkg/errors"
 	"github.com/valyala/fasthttp"
 	"net/http"
 	"net/url"
@@ -29,26 +28,22 @@ func setupRequest(request restql.HTTPRequest, req *fasthttp.Request) error {
 
 	req.SetRequestURIBytes(uri.FullURI())
 
-	if request.Method == http.MethodPost || request.Method == http.MethodPut || request.Method == http.MethodPatch {
-		var data []byte
+	for key, value := range request.Headers {
+		req.Header.Set(key, value)
+	}
 
-		body := request.Body
-		if strBody, ok := body.(string); ok {
-			data = []byte(strBody)
-		} else {
-			jsonData, err := json.Marshal(body)
-			if err != nil {
-				return errors.Wrap(err, "failed to marshal request body")
-			}
+	if request.Method == http.MethodPost || request.Method == http.MethodPut || request.Method == http.MethodPatch || request.Body != nil {
+		contentTypeKey, contentType := contentTypeHeader(request.Headers)
 
-			data = jsonData
+		data, contentType, err := marshalBody(request.Body, contentType)
+		if err != nil {
+			return err
 		}
 
 		req.SetBody(data)
-	}
-
-	for key, value := range request.Headers {
-		req.Header.Set(key, value)
+		if contentType != "" {
+			req.Header.Set(contentTypeKey, contentType)
+		}
 	}
 
 	req.Header.SetMethod(request.Method)
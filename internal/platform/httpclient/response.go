@@ -1,8 +1,12 @@
 package httpclient
 
 import (
+	"bytes"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
 	"github.com/valyala/fasthttp"
+	"mime"
+	"strings"
 	"time"
 )
 
@@ -12,13 +16,74 @@ func unmarshalBody(log restql.Logger, response *fasthttp.Response) (*restql.Resp
 	copy(bb, bodyByte)
 
 	rb := restql.NewResponseBodyFromBytes(log, bb)
-	if !rb.Valid() {
+	if !rb.Valid() && isJSONContentType(string(response.Header.ContentType())) {
 		return rb, errInvalidJSON
 	}
 
 	return rb, nil
 }
 
+var errDecompressedBodyTooLarge = errors.New("decompressed response body exceeds the maximum size")
+
+// limitedBuffer accumulates the decompressed body,
+// failing once it grows beyond the limit, if defined.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (lb *limitedBuffer) Write(p []byte) (int, error) {
+	if lb.limit > 0 && lb.Len()+len(p) > lb.limit {
+		return 0, errDecompressedBodyTooLarge
+	}
+
+	return lb.Buffer.Write(p)
+}
+
+// decompressBody replaces the response body by its content decoded
+// according to the Content-Encoding header, returning the length of
+// the compressed body, or zero if it was not compressed. Bodies that
+// decompress to more than maxSize bytes fail, unless it is zero.
+func decompressBody(response *fasthttp.Response, maxSize int) (int, error) {
+	encoding := strings.ToLower(strings.TrimSpace(string(response.Header.Peek(fasthttp.HeaderContentEncoding))))
+
+	body := &limitedBuffer{limit: maxSize}
+	var err error
+	switch encoding {
+	case "gzip", "x-gzip":
+		_, err = fasthttp.WriteGunzip(body, response.Body())
+	case "br":
+		_, err = fasthttp.WriteUnbrotli(body, response.Body())
+	case "deflate":
+		_, err = fasthttp.WriteInflate(body, response.Body())
+	default:
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to decompress %s response body", encoding)
+	}
+
+	compressedSize := len(response.Body())
+
+	response.SetBodyRaw(body.Bytes())
+	response.Header.Del(fasthttp.HeaderContentEncoding)
+	response.Header.SetContentLength(body.Len())
+
+	return compressedSize, nil
+}
+
+// isJSONContentType tells if the response body is expected to be JSON,
+// since bodies of other types are decoded by the runner.
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return true
+	}
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
 func readHeaders(res *fasthttp.Response) restql.Headers {
 	h := make(restql.Headers)
 	res.Header.VisitAll(func(key, value []byte) {
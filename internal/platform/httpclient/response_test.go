@@ -0,0 +1,89 @@
+package httpclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/valyala/fasthttp"
+)
+
+func TestDecompressBody(t *testing.T) {
+	body := []byte(`{"name": "batman", "city": "gotham", "sidekick": "robin"}`)
+
+	type result struct {
+		Body           string
+		Encoding       string
+		CompressedSize int
+	}
+
+	tests := []struct {
+		name     string
+		encoding string
+		data     []byte
+		expected result
+	}{
+		{"gzip body", "gzip", fasthttp.AppendGzipBytes(nil, body), result{Body: string(body), CompressedSize: len(fasthttp.AppendGzipBytes(nil, body))}},
+		{"brotli body", "br", fasthttp.AppendBrotliBytes(nil, body), result{Body: string(body), CompressedSize: len(fasthttp.AppendBrotliBytes(nil, body))}},
+		{"deflate body", "deflate", fasthttp.AppendDeflateBytes(nil, body), result{Body: string(body), CompressedSize: len(fasthttp.AppendDeflateBytes(nil, body))}},
+		{"uncompressed body", "", body, result{Body: string(body)}},
+		{"unknown encoding", "compress", body, result{Body: string(body), Encoding: "compress"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var response fasthttp.Response
+			if tt.encoding != "" {
+				response.Header.Set(fasthttp.HeaderContentEncoding, tt.encoding)
+			}
+			response.SetBody(tt.data)
+
+			compressedSize, err := decompressBody(&response, 0)
+			test.VerifyError(t, err)
+
+			got := result{
+				Body:           string(response.Body()),
+				Encoding:       string(response.Header.Peek(fasthttp.HeaderContentEncoding)),
+				CompressedSize: compressedSize,
+			}
+
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
+func TestDecompressBodyFailsForInvalidContent(t *testing.T) {
+	var response fasthttp.Response
+	response.Header.Set(fasthttp.HeaderContentEncoding, "gzip")
+	response.SetBody([]byte("batman"))
+
+	_, err := decompressBody(&response, 0)
+	if err == nil {
+		t.Fatal("expected an error when decompressing an invalid gzip body")
+	}
+}
+
+func TestDecompressBodyFailsForBodyLargerThanLimit(t *testing.T) {
+	body := []byte(`{"name": "batman", "city": "gotham", "sidekick": "robin"}`)
+
+	tests := []struct {
+		name     string
+		maxSize  int
+		expected error
+	}{
+		{"body within limit", len(body), nil},
+		{"body larger than limit", len(body) - 1, errDecompressedBodyTooLarge},
+		{"no limit", 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var response fasthttp.Response
+			response.Header.Set(fasthttp.HeaderContentEncoding, "gzip")
+			response.SetBody(fasthttp.AppendGzipBytes(nil, body))
+
+			_, err := decompressBody(&response, tt.maxSize)
+			test.Equal(t, errors.Is(err, tt.expected), true)
+		})
+	}
+}
@@ -26,7 +26,7 @@ type query struct {
 }
 
 type mapping struct {
-	URL    string `json:"url"`
+	restql.MappingDefinition
 	Source string `json:"source"`
 }
 
@@ -75,8 +75,8 @@ func (adm *administrator) TenantMappings(reqCtx *fasthttp.RequestCtx) error {
 	ms := make(map[string]mapping)
 	for resourceName, m := range mappings {
 		ms[resourceName] = mapping{
-			URL:    m.URL(),
-			Source: string(m.Source),
+			MappingDefinition: m.Definition(),
+			Source:            string(m.Source),
 		}
 	}
 
@@ -230,10 +230,6 @@ func (adm *administrator) Query(reqCtx *fasthttp.RequestCtx) error {
 	return Respond(reqCtx, qr, fasthttp.StatusOK, nil)
 }
 
-type mapResourceBody struct {
-	Url string `json:"url"`
-}
-
 func (adm *administrator) CreateResource(reqCtx *fasthttp.RequestCtx) error {
 	ctx := middleware.GetNativeContext(reqCtx)
 	ctx = restql.WithLogger(ctx, adm.log)
@@ -250,15 +246,20 @@ func (adm *administrator) CreateResource(reqCtx *fasthttp.RequestCtx) error {
 		return err
 	}
 
-	var mrb mapResourceBody
+	var definition restql.MappingDefinition
 
 	bytesBody := reqCtx.PostBody()
-	err = json.Unmarshal(bytesBody, &mrb)
+	err = json.Unmarshal(bytesBody, &definition)
+	if err != nil {
+		return err
+	}
+
+	value, err := definition.Encode()
 	if err != nil {
 		return err
 	}
 
-	err = adm.mw.Create(ctx, tenantName, resourceName, mrb.Url)
+	err = adm.mw.Create(ctx, tenantName, resourceName, value)
 	if err != nil {
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
@@ -287,15 +288,20 @@ func (adm *administrator) UpdateResource(reqCtx *fasthttp.RequestCtx) error {
 		return err
 	}
 
-	var mrb mapResourceBody
+	var definition restql.MappingDefinition
 
 	bytesBody := reqCtx.PostBody()
-	err = json.Unmarshal(bytesBody, &mrb)
+	err = json.Unmarshal(bytesBody, &definition)
+	if err != nil {
+		return err
+	}
+
+	value, err := definition.Encode()
 	if err != nil {
 		return err
 	}
 
-	err = adm.mw.Update(ctx, tenantName, resourceName, mrb.Url)
+	err = adm.mw.Update(ctx, tenantName, resourceName, value)
 	if err != nil {
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
@@ -6,11 +6,12 @@ import (
 )
 
 type check struct {
-	build string
+	build   string
+	limiter LimiterStatusReporter
 }
 
-func newCheck(build string) check {
-	return check{build: build}
+func newCheck(build string, limiter LimiterStatusReporter) check {
+	return check{build: build, limiter: limiter}
 }
 
 func (c check) Health(ctx *fasthttp.RequestCtx) error {
@@ -22,3 +23,7 @@ func (c check) ResourceStatus(ctx *fasthttp.RequestCtx) error {
 	ctx.Response.SetBodyString(fmt.Sprintf("RestQL is running with build %s", c.build))
 	return nil
 }
+
+func (c check) ConcurrencyLimit(ctx *fasthttp.RequestCtx) error {
+	return Respond(ctx, c.limiter.LimiterStatus(), fasthttp.StatusOK, nil)
+}
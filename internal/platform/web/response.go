@@ -2,15 +2,20 @@ package web
 
 import (
 	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v6/internal/eval"
 	"github.com/b2wdigital/restQL-golang/v6/internal/parser"
+	"github.com/b2wdigital/restQL-golang/v6/internal/parser/ast"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence"
 	"github.com/b2wdigital/restQL-golang/v6/internal/runner"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/valyala/fasthttp"
 )
@@ -27,8 +32,13 @@ var errToStatusCode = map[error]int{
 	eval.ErrParser:                              fasthttp.StatusInternalServerError,
 	eval.ErrTimeout:                             fasthttp.StatusRequestTimeout,
 	eval.ErrMapping:                             fasthttp.StatusInternalServerError,
+	eval.ErrMissingVariables:                    fasthttp.StatusUnprocessableEntity,
 	runner.ErrMaxQueryDenied:                    fasthttp.StatusInsufficientStorage,
 	runner.ErrMaxGoroutineDenied:                fasthttp.StatusInsufficientStorage,
+	runner.ErrTenantQueryQuotaDenied:            fasthttp.StatusTooManyRequests,
+	runner.ErrNamespaceQueryQuotaDenied:         fasthttp.StatusTooManyRequests,
+	runner.ErrTenantGoroutineQuotaDenied:        fasthttp.StatusTooManyRequests,
+	runner.ErrNamespaceGoroutineQuotaDenied:     fasthttp.StatusTooManyRequests,
 	parser.ErrInvalidQuery:                      fasthttp.StatusUnprocessableEntity,
 	persistence.ErrSetResourceMappingNotAllowed: fasthttp.StatusUnauthorized,
 	persistence.ErrUpdateQueryNotAllowed:        fasthttp.StatusUnauthorized,
@@ -38,11 +48,76 @@ var errToStatusCode = map[error]int{
 	errFailedToReadRequestBody:                  fasthttp.StatusBadRequest,
 }
 
+var errToProblemCode = map[error]string{
+	restql.ErrMappingsNotFound:                  "restql/mapping-not-found",
+	restql.ErrQueryNotFound:                     "restql/query-not-found",
+	restql.ErrNamespaceNotFound:                 "restql/namespace-not-found",
+	restql.ErrQueryNotFoundInDatabase:           "restql/query-not-found",
+	restql.ErrMappingsNotFoundInDatabase:        "restql/mapping-not-found",
+	restql.ErrMappingAlreadyExistsInDatabase:    "restql/mapping-already-exists",
+	restql.ErrDatabaseCommunicationFailed:       "restql/database-unavailable",
+	eval.ErrValidation:                          "restql/validation-error",
+	eval.ErrParser:                              "restql/parse-error",
+	eval.ErrTimeout:                             "restql/query-timeout",
+	eval.ErrMapping:                             "restql/mapping-not-found",
+	eval.ErrMissingVariables:                    "restql/missing-variables",
+	runner.ErrMaxQueryDenied:                    "restql/concurrency-denied",
+	runner.ErrMaxGoroutineDenied:                "restql/concurrency-denied",
+	runner.ErrTenantQueryQuotaDenied:            "restql/quota-exceeded",
+	runner.ErrNamespaceQueryQuotaDenied:         "restql/quota-exceeded",
+	runner.ErrTenantGoroutineQuotaDenied:        "restql/quota-exceeded",
+	runner.ErrNamespaceGoroutineQuotaDenied:     "restql/quota-exceeded",
+	parser.ErrInvalidQuery:                      "restql/parse-error",
+	persistence.ErrSetResourceMappingNotAllowed: "restql/operation-not-allowed",
+	persistence.ErrUpdateQueryNotAllowed:        "restql/operation-not-allowed",
+	errPathParamNotFound:                        "restql/missing-path-param",
+	errInvalidTenant:                            "restql/invalid-tenant",
+	errInvalidRevisionType:                      "restql/invalid-revision",
+	errFailedToReadRequestBody:                  "restql/invalid-request-body",
+}
+
+const (
+	problemContentType = "application/problem+json"
+	unknownProblemCode = "restql/internal-error"
+)
+
 // ErrorResponse is the form used for API responses from failures in the API.
 type ErrorResponse struct {
 	Error string `json:"error"`
 }
 
+// ProblemResponse is the RFC 7807 form used for API responses from
+// failures in the API, when problem details are enabled.
+type ProblemResponse struct {
+	Type      string           `json:"type"`
+	Title     string           `json:"title"`
+	Status    int              `json:"status"`
+	Detail    string           `json:"detail"`
+	Code      string           `json:"code"`
+	RequestID string           `json:"request-id,omitempty"`
+	Position  *ProblemPosition `json:"position,omitempty"`
+	Expected  []string         `json:"expected,omitempty"`
+
+	MissingVariables []string `json:"missingVariables,omitempty"`
+}
+
+// ProblemPosition represents where in the query text a parsing failure happened.
+type ProblemPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+	Offset int `json:"offset"`
+}
+
+// problemDetailsKey is the request user value that enables problem details
+// on RespondError, holding the name of the header with the request id.
+const problemDetailsKey = "restql-problem-details"
+
+// EnableProblemDetails makes RespondError write failures on the given request
+// in the RFC 7807 form, including the request id present on the given header.
+func EnableProblemDetails(ctx *fasthttp.RequestCtx, requestIDHeader string) {
+	ctx.SetUserValue(problemDetailsKey, requestIDHeader)
+}
+
 // Respond write the information back to the client.
 func Respond(ctx *fasthttp.RequestCtx, data interface{}, statusCode int, headers map[string]string) error {
 	ctx.Response.Header.SetContentType("application/json; charset=utf-8")
@@ -61,10 +136,131 @@ func Respond(ctx *fasthttp.RequestCtx, data interface{}, statusCode int, headers
 	return nil
 }
 
+// RespondWithETag write the information back to the client
+// identified by a strong ETag computed over the serialized data.
+// If the client already holds the same representation, as informed
+// by the If-None-Match header, a 304 Not Modified is sent without a body.
+func RespondWithETag(ctx *fasthttp.RequestCtx, data interface{}, statusCode int, headers map[string]string) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	etag := makeETag(body)
+
+	ctx.Response.Header.SetContentType("application/json; charset=utf-8")
+	for k, v := range headers {
+		ctx.Response.Header.Set(k, v)
+	}
+	ctx.Response.Header.Set(fasthttp.HeaderETag, etag)
+
+	ifNoneMatch := string(ctx.Request.Header.Peek(fasthttp.HeaderIfNoneMatch))
+	if statusCode >= 200 && statusCode < 300 && etagMatches(ifNoneMatch, etag) {
+		ctx.Response.SetStatusCode(fasthttp.StatusNotModified)
+		return nil
+	}
+
+	ctx.Response.SetStatusCode(statusCode)
+	ctx.Response.SetBodyRaw(append(body, '\n'))
+
+	return nil
+}
+
+// RawResult returns the result of a query made of a single `from`
+// statement with the `raw` flag, whose upstream response is sent
+// to the client as is. Statements that failed to be executed or
+// are filtered by restQL are not sent raw, neither are the hidden
+// ones, which must be removed from the result beforehand.
+func RawResult(query domain.Query, queryResult domain.Resources) (restql.DoneResource, bool) {
+	if len(query.Statements) != 1 {
+		return restql.DoneResource{}, false
+	}
+
+	stmt := query.Statements[0]
+	if stmt.Method != domain.FromMethod || !stmt.Raw || len(stmt.Only) > 0 {
+		return restql.DoneResource{}, false
+	}
+
+	dr, ok := queryResult[domain.NewResourceID(stmt)].(restql.DoneResource)
+	if !ok || dr.ErrorKind != "" || dr.RawResponseBody == nil {
+		return restql.DoneResource{}, false
+	}
+
+	return dr, true
+}
+
+// RespondRaw write the upstream response of a statement back
+// to the client, with the upstream status code and content type.
+func RespondRaw(ctx *fasthttp.RequestCtx, dr restql.DoneResource, headers map[string]string) {
+	for k, v := range headers {
+		ctx.Response.Header.Set(k, v)
+	}
+
+	contentType := "application/octet-stream"
+	for k, v := range dr.ResponseHeaders {
+		if strings.EqualFold(k, fasthttp.HeaderContentType) {
+			contentType = v
+		}
+	}
+	ctx.Response.Header.SetContentType(contentType)
+
+	ctx.Response.SetStatusCode(dr.Status)
+	ctx.Response.SetBodyRaw(dr.RawResponseBody.Bytes())
+}
+
+func makeETag(body []byte) string {
+	sum := sha1.Sum(body)
+
+	var buf bytes.Buffer
+	buf.WriteRune('"')
+	buf.WriteString(hex.EncodeToString(sum[:]))
+	buf.WriteRune('"')
+
+	return buf.String()
+}
+
+// etagMatches implements the weak comparison required by
+// the If-None-Match header on the given list of entity tags.
+func etagMatches(ifNoneMatch string, etag string) bool {
+	ifNoneMatch = strings.TrimSpace(ifNoneMatch)
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	if ifNoneMatch == "*" {
+		return true
+	}
+
+	for _, candidate := range strings.FieldsFunc(ifNoneMatch, isComma) {
+		candidate = strings.TrimSpace(candidate)
+		candidate = strings.TrimPrefix(candidate, "W/")
+
+		if candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isComma(r rune) bool {
+	return r == ','
+}
+
 // RespondError translate the error and write it back to the client.
 func RespondError(ctx *fasthttp.RequestCtx, err error, toStatusCode map[error]int) error {
 	status := findStatusCode(toStatusCode, err)
 
+	if requestIDHeader, ok := ctx.UserValue(problemDetailsKey).(string); ok {
+		requestID := ""
+		if requestIDHeader != "" {
+			requestID = string(ctx.Request.Header.Peek(requestIDHeader))
+		}
+
+		pr := MakeProblemResponse(err, status, requestID)
+		return Respond(ctx, pr, status, map[string]string{fasthttp.HeaderContentType: problemContentType})
+	}
+
 	er := ErrorResponse{Error: err.Error()}
 	if err := Respond(ctx, er, status, nil); err != nil {
 		return err
@@ -81,6 +277,42 @@ func findStatusCode(toStatusCode map[error]int, err error) int {
 	return fasthttp.StatusInternalServerError
 }
 
+// MakeProblemResponse builds the RFC 7807 representation of the error.
+func MakeProblemResponse(err error, status int, requestID string) ProblemResponse {
+	code := findProblemCode(err)
+
+	pr := ProblemResponse{
+		Type:      code,
+		Title:     fasthttp.StatusMessage(status),
+		Status:    status,
+		Detail:    err.Error(),
+		Code:      code,
+		RequestID: requestID,
+	}
+
+	var syntaxErr ast.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		pr.Position = &ProblemPosition{Line: syntaxErr.Line, Column: syntaxErr.Column, Offset: syntaxErr.Offset}
+		pr.Expected = syntaxErr.Expected
+	}
+
+	var missingErr eval.MissingVariablesError
+	if errors.As(err, &missingErr) {
+		pr.MissingVariables = missingErr.Variables
+	}
+
+	return pr
+}
+
+func findProblemCode(err error) string {
+	for e, code := range errToProblemCode {
+		if errors.Is(err, e) {
+			return code
+		}
+	}
+	return unknownProblemCode
+}
+
 // StatementDebugging represents the client format of debugging information
 type StatementDebugging struct {
 	Method          string                 `json:"method,omitempty"`
@@ -90,11 +322,18 @@ type StatementDebugging struct {
 	Params          map[string]interface{} `json:"params,omitempty"`
 	RequestBody     interface{}            `json:"request-body,omitempty"`
 	ResponseTime    int64                  `json:"response-time,omitempty"`
+	Hedge           string                 `json:"hedge,omitempty"`
+
+	ResponseSize           int `json:"response-size,omitempty"`
+	ResponseCompressedSize int `json:"response-compressed-size,omitempty"`
 }
 
 // StatementMetadata represents the client format of metadata
 type StatementMetadata struct {
 	IgnoreErrors string `json:"ignore-errors,omitempty"`
+	Skipped      bool   `json:"skipped,omitempty"`
+	ErrorKind    string `json:"error-kind,omitempty"`
+	ErrorMessage string `json:"error-message,omitempty"`
 }
 
 // StatementDetails represents the client format of the statement details
@@ -118,8 +357,30 @@ type QueryResponse struct {
 	Headers    map[string]string
 }
 
+// Strategies available to aggregate the statement results
+// status codes into the response status code.
+const (
+	StatusStrategyMax                  = "max"
+	StatusStrategyFirstFailure         = "first-failure"
+	StatusStrategyMajority             = "majority"
+	StatusStrategyAlways200WithDetails = "always-200-with-details"
+)
+
+// StatusCodeOptions configures how the response status code
+// is calculated from the statement results. ErrorKindStatus
+// replaces the status of the statements that failed with the
+// given error kind. When From is present in the results, its
+// status is used, otherwise the Strategy is applied following
+// the statements Order.
+type StatusCodeOptions struct {
+	ErrorKindStatus map[string]int
+	Strategy        string
+	From            domain.ResourceID
+	Order           []domain.ResourceID
+}
+
 // MakeQueryResponse create a query execution response for the client.
-func MakeQueryResponse(queryResult domain.Resources, debug bool) (QueryResponse, error) {
+func MakeQueryResponse(queryResult domain.Resources, debug bool, statusOptions StatusCodeOptions) (QueryResponse, error) {
 	m := make(map[string]StatementResult)
 	for key, resource := range queryResult {
 		r, err := parseResource(resource, debug)
@@ -130,7 +391,7 @@ func MakeQueryResponse(queryResult domain.Resources, debug bool) (QueryResponse,
 		m[string(key)] = r
 	}
 
-	statusCode := CalculateStatusCode(queryResult)
+	statusCode := CalculateStatusCode(queryResult, statusOptions)
 	headers := makeHeaders(queryResult)
 	return QueryResponse{Body: m, StatusCode: statusCode, Headers: headers}, nil
 }
@@ -183,6 +444,9 @@ func parseDetails(resource restql.DoneResource, debug bool) StatementDetails {
 	if resource.IgnoreErrors {
 		metadata.IgnoreErrors = "ignore"
 	}
+	metadata.Skipped = resource.Skipped
+	metadata.ErrorKind = resource.ErrorKind
+	metadata.ErrorMessage = resource.ErrorMessage
 
 	sd := StatementDetails{
 		Status:   resource.Status,
@@ -206,6 +470,23 @@ func parseDebug(resource restql.DoneResource) *StatementDebugging {
 		Params:          resource.RequestParams,
 		RequestBody:     resource.RequestBody,
 		ResponseTime:    resource.ResponseTime,
+		Hedge:           parseHedge(resource),
+
+		ResponseSize:           resource.ResponseSize,
+		ResponseCompressedSize: resource.ResponseCompressedSize,
+	}
+}
+
+// parseHedge tells if the duplicate request sent
+// for a hedged statement returned first or not.
+func parseHedge(resource restql.DoneResource) string {
+	switch {
+	case !resource.Hedged:
+		return ""
+	case resource.HedgeWon:
+		return "won"
+	default:
+		return "lost"
 	}
 }
 
@@ -216,28 +497,85 @@ func parseDebug(resource restql.DoneResource) *StatementDebugging {
 // 0 => 500
 // 204 => 200
 // 201 => 200
-func CalculateStatusCode(queryResult domain.Resources) int {
-	results := make([]interface{}, len(queryResult))
-	index := 0
-	for _, r := range queryResult {
-		results[index] = r
-		index++
+//
+// Results skipped due to a fail-fast query do not
+// contribute, hence the failed statement status is used,
+// while results successful due to `success-on` count as 200.
+// The status of results with an error kind present in the
+// options is replaced by the one defined for it.
+//
+// The greater status is the default strategy, the others are:
+// first-failure, which takes the status of the first failed
+// statement in the query order; majority, which takes the most
+// frequent status, the greater one on ties; and
+// always-200-with-details, which always returns 200 leaving the
+// statement status codes only on the details.
+func CalculateStatusCode(queryResult domain.Resources, options StatusCodeOptions) int {
+	if options.From != "" {
+		if r, found := queryResult[options.From]; found {
+			return calculateResultStatusCode(r, options)
+		}
 	}
 
-	maxStatusCode := findMaxStatusCode(results)
+	results := orderResults(queryResult, options.Order)
 
-	return maxStatusCode
+	switch options.Strategy {
+	case StatusStrategyFirstFailure:
+		return findFirstFailureStatusCode(results, options)
+	case StatusStrategyMajority:
+		return findMajorityStatusCode(results, options)
+	case StatusStrategyAlways200WithDetails:
+		return 200
+	default:
+		return findMaxStatusCode(results, options)
+	}
+}
+
+// orderResults lists the results following the given order,
+// with the ones absent from it sorted by resource id.
+func orderResults(queryResult domain.Resources, order []domain.ResourceID) []interface{} {
+	results := make([]interface{}, 0, len(queryResult))
+	ordered := make(map[domain.ResourceID]bool, len(order))
+	for _, id := range order {
+		if r, found := queryResult[id]; found && !ordered[id] {
+			ordered[id] = true
+			results = append(results, r)
+		}
+	}
+
+	var remaining []string
+	for id := range queryResult {
+		if !ordered[id] {
+			remaining = append(remaining, string(id))
+		}
+	}
+	sort.Strings(remaining)
+
+	for _, id := range remaining {
+		results = append(results, queryResult[domain.ResourceID(id)])
+	}
+
+	return results
 }
 
 var statusNormalization = map[int]int{0: 500, 204: 200, 201: 200}
 
-func calculateResultStatusCode(result interface{}) int {
+func calculateResultStatusCode(result interface{}, options StatusCodeOptions) int {
 	switch r := result.(type) {
 	case restql.DoneResource:
-		if r.IgnoreErrors {
+		if r.IgnoreErrors || r.Skipped {
 			return 200
 		}
 
+		// a status code defined as success by the statement
+		if r.Success && r.Status >= 400 {
+			return 200
+		}
+
+		if status, found := options.ErrorKindStatus[r.ErrorKind]; found && r.ErrorKind != "" {
+			return status
+		}
+
 		status := r.Status
 		normalizedStatus, found := statusNormalization[status]
 		if found {
@@ -246,16 +584,16 @@ func calculateResultStatusCode(result interface{}) int {
 
 		return status
 	case restql.DoneResources:
-		return findMaxStatusCode(r)
+		return findMaxStatusCode(r, options)
 	default:
 		return 500
 	}
 }
 
-func findMaxStatusCode(results []interface{}) int {
+func findMaxStatusCode(results []interface{}, options StatusCodeOptions) int {
 	resourceStatuses := make([]int, len(results))
 	for i, result := range results {
-		resourceStatuses[i] = calculateResultStatusCode(result)
+		resourceStatuses[i] = calculateResultStatusCode(result, options)
 	}
 
 	maxStatusCode := 200
@@ -267,6 +605,32 @@ func findMaxStatusCode(results []interface{}) int {
 	return maxStatusCode
 }
 
+func findFirstFailureStatusCode(results []interface{}, options StatusCodeOptions) int {
+	for _, result := range results {
+		status := calculateResultStatusCode(result, options)
+		if status >= 400 {
+			return status
+		}
+	}
+
+	return findMaxStatusCode(results, options)
+}
+
+func findMajorityStatusCode(results []interface{}, options StatusCodeOptions) int {
+	counts := make(map[int]int)
+	for _, result := range results {
+		counts[calculateResultStatusCode(result, options)]++
+	}
+
+	majorityStatusCode, majorityCount := 200, 0
+	for status, count := range counts {
+		if count > majorityCount || (count == majorityCount && status > majorityStatusCode) {
+			majorityStatusCode, majorityCount = status, count
+		}
+	}
+	return majorityStatusCode
+}
+
 func makeHeaders(queryResult domain.Resources) map[string]string {
 	resourceHeaders := makeResourceHeaders(queryResult)
 	ccHeaders := makeCacheControlHeaders(queryResult)
@@ -329,39 +693,73 @@ func calculateCacheControl(queryResult domain.Resources) restql.ResourceCacheCon
 	return findMinCacheControl(results)
 }
 
+// findMinCacheControl aggregates the statements cache directives
+// into the most restrictive set of them: `no-store` takes precedence
+// over `no-cache`, which discards any expiration directive, `private`
+// overrides `public`, `must-revalidate` is kept if any statement
+// requires it and time based directives use the lowest value present.
+// A statement without an expiration directive makes the whole response
+// uncacheable, discarding the time based directives of the others.
 func findMinCacheControl(results []interface{}) restql.ResourceCacheControl {
-	resourceCacheControls := make([]restql.ResourceCacheControl, len(results))
-	for i, result := range results {
-		resourceCacheControls[i] = calculateResultCacheControl(result)
-	}
+	var minCacheControl restql.ResourceCacheControl
+	uncached := false
 
-	minCacheControl := restql.ResourceCacheControl{
-		MaxAge:  restql.ResourceCacheControlValue{Exist: false},
-		SMaxAge: restql.ResourceCacheControlValue{Exist: false},
-	}
+	for _, result := range results {
+		cc := calculateResultCacheControl(result)
+		if !hasExpiration(cc) {
+			uncached = true
+		}
 
-	for _, cc := range resourceCacheControls {
-		switch {
-		case minCacheControl.NoCache:
-			continue
-		case cc.NoCache:
-			minCacheControl.NoCache = true
-		default:
-			if !minCacheControl.MaxAge.Exist || cc.MaxAge.Time < minCacheControl.MaxAge.Time {
-				minCacheControl.MaxAge = cc.MaxAge
-			}
+		minCacheControl.NoCache = minCacheControl.NoCache || cc.NoCache
+		minCacheControl.NoStore = minCacheControl.NoStore || cc.NoStore
+		minCacheControl.Private = minCacheControl.Private || cc.Private
+		minCacheControl.Public = minCacheControl.Public || cc.Public
+		minCacheControl.MustRevalidate = minCacheControl.MustRevalidate || cc.MustRevalidate
 
-			if !minCacheControl.SMaxAge.Exist || cc.SMaxAge.Time < minCacheControl.SMaxAge.Time {
-				minCacheControl.SMaxAge = cc.SMaxAge
-			}
+		minCacheControl.MaxAge = minCacheControlValue(minCacheControl.MaxAge, cc.MaxAge)
+		minCacheControl.SMaxAge = minCacheControlValue(minCacheControl.SMaxAge, cc.SMaxAge)
+		minCacheControl.StaleWhileRevalidate = minCacheControlValue(minCacheControl.StaleWhileRevalidate, cc.StaleWhileRevalidate)
+		minCacheControl.StaleIfError = minCacheControlValue(minCacheControl.StaleIfError, cc.StaleIfError)
+	}
 
-			minCacheControl.NoCache = false
+	if minCacheControl.NoStore || minCacheControl.NoCache {
+		return restql.ResourceCacheControl{
+			NoCache: minCacheControl.NoCache,
+			NoStore: minCacheControl.NoStore,
+			Private: minCacheControl.Private,
 		}
 	}
 
+	if uncached {
+		minCacheControl.MaxAge = restql.ResourceCacheControlValue{}
+		minCacheControl.SMaxAge = restql.ResourceCacheControlValue{}
+		minCacheControl.StaleWhileRevalidate = restql.ResourceCacheControlValue{}
+		minCacheControl.StaleIfError = restql.ResourceCacheControlValue{}
+	}
+
+	if minCacheControl.Private {
+		minCacheControl.Public = false
+	}
+
 	return minCacheControl
 }
 
+func hasExpiration(cc restql.ResourceCacheControl) bool {
+	return cc.MaxAge.Exist || cc.SMaxAge.Exist
+}
+
+func minCacheControlValue(current, candidate restql.ResourceCacheControlValue) restql.ResourceCacheControlValue {
+	if !candidate.Exist {
+		return current
+	}
+
+	if !current.Exist || candidate.Time < current.Time {
+		return candidate
+	}
+
+	return current
+}
+
 func calculateResultCacheControl(result interface{}) restql.ResourceCacheControl {
 	switch result := result.(type) {
 	case restql.DoneResource:
@@ -374,26 +772,44 @@ func calculateResultCacheControl(result interface{}) restql.ResourceCacheControl
 }
 
 func generateCacheControlString(cacheControl restql.ResourceCacheControl) string {
-	var buf bytes.Buffer
+	var directives []string
+
+	if cacheControl.Private {
+		directives = append(directives, "private")
+	}
 
-	if cacheControl.NoCache {
-		return "no-cache"
+	switch {
+	case cacheControl.NoStore:
+		return strings.Join(append(directives, "no-store"), ", ")
+	case cacheControl.NoCache:
+		return strings.Join(append(directives, "no-cache"), ", ")
+	}
+
+	if cacheControl.Public {
+		directives = append(directives, "public")
 	}
 
 	if cacheControl.MaxAge.Exist {
-		buf.WriteString("max-age=")
-		buf.WriteString(strconv.Itoa(cacheControl.MaxAge.Time))
+		directives = append(directives, "max-age="+strconv.Itoa(cacheControl.MaxAge.Time))
 	}
 
 	if cacheControl.SMaxAge.Exist {
-		if buf.Len() > 0 {
-			buf.WriteString(", ")
-		}
-		buf.WriteString("s-maxage=")
-		buf.WriteString(strconv.Itoa(cacheControl.SMaxAge.Time))
+		directives = append(directives, "s-maxage="+strconv.Itoa(cacheControl.SMaxAge.Time))
 	}
 
-	return buf.String()
+	if cacheControl.MustRevalidate {
+		directives = append(directives, "must-revalidate")
+	}
+
+	if cacheControl.StaleWhileRevalidate.Exist {
+		directives = append(directives, "stale-while-revalidate="+strconv.Itoa(cacheControl.StaleWhileRevalidate.Time))
+	}
+
+	if cacheControl.StaleIfError.Exist {
+		directives = append(directives, "stale-if-error="+strconv.Itoa(cacheControl.StaleIfError.Time))
+	}
+
+	return strings.Join(directives, ", ")
 }
 
 func appendMap(m1 map[string]string, m2 map[string]string) map[string]string {
@@ -0,0 +1,184 @@
+package web
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+)
+
+// Headers set on responses served by a response cache enabled restQL.
+const (
+	cacheStatusHeader = "X-Restql-Cache"
+	ageHeader         = "Age"
+)
+
+// Values of the X-Restql-Cache header.
+const (
+	cacheHit  = "HIT"
+	cacheMiss = "MISS"
+)
+
+// ResponseCacheKeyOptions defines which values from the
+// client input identify a cached saved query response.
+// When no Params are defined, all of them are used.
+type ResponseCacheKeyOptions struct {
+	Params  []string
+	Headers []string
+}
+
+// MakeResponseCacheKey builds the response cache key from the saved query
+// identity and the input params and headers selected by the options.
+// Each component is prefixed by its length, so values containing
+// the separator cannot produce the same key.
+func MakeResponseCacheKey(options restql.QueryOptions, input restql.QueryInput, keyOptions ResponseCacheKeyOptions) string {
+	var buf bytes.Buffer
+
+	writeKeyComponent(&buf, options.Namespace)
+	buf.WriteRune('|')
+	writeKeyComponent(&buf, options.Id)
+	buf.WriteRune('|')
+	writeKeyComponent(&buf, strconv.Itoa(options.Revision))
+	buf.WriteRune('|')
+	writeKeyComponent(&buf, options.Tenant)
+
+	for _, name := range keyParams(input, keyOptions) {
+		value, found := input.Params[name]
+		if !found {
+			continue
+		}
+
+		buf.WriteString("|p:")
+		writeKeyComponent(&buf, name)
+		buf.WriteRune('=')
+		writeKeyComponent(&buf, fmt.Sprintf("%v", value))
+	}
+
+	headers := sortedCopy(keyOptions.Headers)
+	for _, name := range headers {
+		value, found := findHeader(input.Headers, name)
+		if !found {
+			continue
+		}
+
+		buf.WriteString("|h:")
+		writeKeyComponent(&buf, strings.ToLower(name))
+		buf.WriteRune('=')
+		writeKeyComponent(&buf, value)
+	}
+
+	return buf.String()
+}
+
+// keyParams returns the sorted names of the params that are part
+// of the key, which are all of the input params, except the debug
+// one, when the options do not select them.
+func keyParams(input restql.QueryInput, keyOptions ResponseCacheKeyOptions) []string {
+	if len(keyOptions.Params) > 0 {
+		return sortedCopy(keyOptions.Params)
+	}
+
+	params := make([]string, 0, len(input.Params))
+	for name := range input.Params {
+		if name == debugParamName {
+			continue
+		}
+		params = append(params, name)
+	}
+	sort.Strings(params)
+
+	return params
+}
+
+func writeKeyComponent(buf *bytes.Buffer, value string) {
+	buf.WriteString(strconv.Itoa(len(value)))
+	buf.WriteRune(':')
+	buf.WriteString(value)
+}
+
+func sortedCopy(s []string) []string {
+	r := make([]string, len(s))
+	copy(r, s)
+	sort.Strings(r)
+	return r
+}
+
+func findHeader(headers map[string]string, name string) (string, bool) {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// IsCacheableResponse returns true when all query statements
+// use the `from` method and all results have succeeded.
+// The result must include the hidden statements, since their
+// failures also affect the response.
+func IsCacheableResponse(query domain.Query, queryResult domain.Resources) bool {
+	for _, stmt := range query.Statements {
+		if stmt.Method != domain.FromMethod {
+			return false
+		}
+	}
+
+	for _, r := range queryResult {
+		if !isSuccessfulResult(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isSuccessfulResult(result interface{}) bool {
+	switch r := result.(type) {
+	case restql.DoneResource:
+		return r.Success
+	case restql.DoneResources:
+		for _, dr := range r {
+			if !isSuccessfulResult(dr) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// CalculateResponseCacheTTL returns the time a query response
+// can be kept in cache based on the statements cache directives.
+// The s-maxage directive is preferred over the max-age one,
+// since the response cache is shared by all clients, and
+// responses marked as private are never stored.
+func CalculateResponseCacheTTL(queryResult domain.Resources) (time.Duration, bool) {
+	cacheControl := calculateCacheControl(queryResult)
+
+	switch {
+	case cacheControl.NoCache, cacheControl.NoStore, cacheControl.Private:
+		return 0, false
+	case cacheControl.SMaxAge.Exist && cacheControl.SMaxAge.Time > 0:
+		return time.Duration(cacheControl.SMaxAge.Time) * time.Second, true
+	case cacheControl.MaxAge.Exist && cacheControl.MaxAge.Time > 0:
+		return time.Duration(cacheControl.MaxAge.Time) * time.Second, true
+	default:
+		return 0, false
+	}
+}
+
+func withCacheHeaders(headers map[string]string, status string, age time.Duration) map[string]string {
+	cacheHeaders := map[string]string{
+		cacheStatusHeader: status,
+		ageHeader:         strconv.Itoa(int(age.Seconds())),
+	}
+
+	return appendMap(headers, cacheHeaders)
+}
@@ -0,0 +1,211 @@
+package web_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestMakeResponseCacheKey(t *testing.T) {
+	options := restql.QueryOptions{Namespace: "heroes", Id: "hero-by-id", Revision: 2, Tenant: "dc"}
+
+	tests := []struct {
+		name       string
+		input      restql.QueryInput
+		keyOptions web.ResponseCacheKeyOptions
+		expected   string
+	}{
+		{
+			"should make key from query identity",
+			restql.QueryInput{},
+			web.ResponseCacheKeyOptions{},
+			"6:heroes|10:hero-by-id|1:2|2:dc",
+		},
+		{
+			"should make key with all params except debug when none is selected",
+			restql.QueryInput{Params: map[string]interface{}{"page": "2", "id": "1", "_debug": "true"}},
+			web.ResponseCacheKeyOptions{},
+			"6:heroes|10:hero-by-id|1:2|2:dc|p:2:id=1:1|p:4:page=1:2",
+		},
+		{
+			"should make key with selected params",
+			restql.QueryInput{Params: map[string]interface{}{"id": "1", "page": "2", "tid": "abc"}},
+			web.ResponseCacheKeyOptions{Params: []string{"page", "id"}},
+			"6:heroes|10:hero-by-id|1:2|2:dc|p:2:id=1:1|p:4:page=1:2",
+		},
+		{
+			"should make key with list params",
+			restql.QueryInput{Params: map[string]interface{}{"id": []interface{}{"1", "2"}}},
+			web.ResponseCacheKeyOptions{Params: []string{"id"}},
+			"6:heroes|10:hero-by-id|1:2|2:dc|p:2:id=5:[1 2]",
+		},
+		{
+			"should make key with selected headers ignoring case",
+			restql.QueryInput{Headers: map[string]string{"X-Country": "BR", "X-Tid": "abc"}},
+			web.ResponseCacheKeyOptions{Headers: []string{"x-country"}},
+			"6:heroes|10:hero-by-id|1:2|2:dc|h:9:x-country=2:BR",
+		},
+		{
+			"should ignore selected values not present in input",
+			restql.QueryInput{},
+			web.ResponseCacheKeyOptions{Params: []string{"id"}, Headers: []string{"X-Country"}},
+			"6:heroes|10:hero-by-id|1:2|2:dc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := web.MakeResponseCacheKey(options, tt.input, tt.keyOptions)
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
+func TestMakeResponseCacheKeyWithoutCollision(t *testing.T) {
+	options := restql.QueryOptions{Namespace: "heroes", Id: "hero-by-id", Revision: 1, Tenant: "dc"}
+
+	first := web.MakeResponseCacheKey(options, restql.QueryInput{Params: map[string]interface{}{"a": "1|p:b=2"}}, web.ResponseCacheKeyOptions{})
+	second := web.MakeResponseCacheKey(options, restql.QueryInput{Params: map[string]interface{}{"a": "1", "b": "2"}}, web.ResponseCacheKeyOptions{})
+
+	if first == second {
+		t.Errorf("expected different keys for different params, got %s", first)
+	}
+}
+
+func TestIsCacheableResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       domain.Query
+		queryResult domain.Resources
+		expected    bool
+	}{
+		{
+			"should be cacheable when all statements are from and succeeded",
+			domain.Query{Statements: []domain.Statement{
+				{Method: domain.FromMethod, Resource: "hero"},
+				{Method: domain.FromMethod, Resource: "sidekick"},
+			}},
+			domain.Resources{
+				"hero":     restql.DoneResource{Status: 200, Success: true},
+				"sidekick": restql.DoneResources{restql.DoneResource{Status: 200, Success: true}},
+			},
+			true,
+		},
+		{
+			"should not be cacheable when a statement is not from",
+			domain.Query{Statements: []domain.Statement{
+				{Method: domain.FromMethod, Resource: "hero"},
+				{Method: domain.ToMethod, Resource: "sidekick"},
+			}},
+			domain.Resources{
+				"hero":     restql.DoneResource{Status: 200, Success: true},
+				"sidekick": restql.DoneResource{Status: 201, Success: true},
+			},
+			false,
+		},
+		{
+			"should not be cacheable when a statement failed",
+			domain.Query{Statements: []domain.Statement{
+				{Method: domain.FromMethod, Resource: "hero"},
+			}},
+			domain.Resources{
+				"hero": restql.DoneResource{Status: 404, Success: false, IgnoreErrors: true},
+			},
+			false,
+		},
+		{
+			"should not be cacheable when a multiplexed statement failed",
+			domain.Query{Statements: []domain.Statement{
+				{Method: domain.FromMethod, Resource: "hero"},
+			}},
+			domain.Resources{
+				"hero": restql.DoneResources{
+					restql.DoneResource{Status: 200, Success: true},
+					restql.DoneResource{Status: 500, Success: false},
+				},
+			},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := web.IsCacheableResponse(tt.query, tt.queryResult)
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
+func TestCalculateResponseCacheTTL(t *testing.T) {
+	tests := []struct {
+		name        string
+		queryResult domain.Resources
+		expected    time.Duration
+		expectedOk  bool
+	}{
+		{
+			"should not have ttl without cache directives",
+			domain.Resources{"hero": restql.DoneResource{Status: 200, Success: true}},
+			0,
+			false,
+		},
+		{
+			"should not have ttl when no-cache is present",
+			domain.Resources{
+				"hero":     restql.DoneResource{CacheControl: restql.ResourceCacheControl{MaxAge: restql.ResourceCacheControlValue{Exist: true, Time: 60}}},
+				"sidekick": restql.DoneResource{CacheControl: restql.ResourceCacheControl{NoCache: true}},
+			},
+			0,
+			false,
+		},
+		{
+			"should not have ttl when response is private",
+			domain.Resources{
+				"hero": restql.DoneResource{CacheControl: restql.ResourceCacheControl{Private: true, MaxAge: restql.ResourceCacheControlValue{Exist: true, Time: 60}}},
+			},
+			0,
+			false,
+		},
+		{
+			"should not have ttl when a statement has no cache directives",
+			domain.Resources{
+				"hero":     restql.DoneResource{CacheControl: restql.ResourceCacheControl{MaxAge: restql.ResourceCacheControlValue{Exist: true, Time: 600}}},
+				"sidekick": restql.DoneResource{Status: 200, Success: true},
+			},
+			0,
+			false,
+		},
+		{
+			"should use minimum max-age as ttl",
+			domain.Resources{
+				"hero":     restql.DoneResource{CacheControl: restql.ResourceCacheControl{MaxAge: restql.ResourceCacheControlValue{Exist: true, Time: 60}}},
+				"sidekick": restql.DoneResource{CacheControl: restql.ResourceCacheControl{MaxAge: restql.ResourceCacheControlValue{Exist: true, Time: 30}}},
+			},
+			30 * time.Second,
+			true,
+		},
+		{
+			"should prefer s-maxage over max-age as ttl",
+			domain.Resources{
+				"hero": restql.DoneResource{CacheControl: restql.ResourceCacheControl{
+					MaxAge:  restql.ResourceCacheControlValue{Exist: true, Time: 60},
+					SMaxAge: restql.ResourceCacheControlValue{Exist: true, Time: 600},
+				}},
+			},
+			600 * time.Second,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := web.CalculateResponseCacheTTL(tt.queryResult)
+			test.Equal(t, got, tt.expected)
+			test.Equal(t, ok, tt.expectedOk)
+		})
+	}
+}
@@ -1,13 +1,20 @@
 package web_test
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/eval"
+	"github.com/b2wdigital/restQL-golang/v6/internal/parser/ast"
+	"github.com/b2wdigital/restQL-golang/v6/internal/runner"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"testing"
+	"time"
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web"
 	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/valyala/fasthttp"
 )
 
 func TestMakeQueryResponse(t *testing.T) {
@@ -60,6 +67,32 @@ func TestMakeQueryResponse(t *testing.T) {
 				Headers: map[string]string{},
 			},
 		},
+		{
+			"should make response with error kind on metadata",
+			domain.Resources{
+				"hero": restql.DoneResource{
+					Status:       0,
+					Success:      false,
+					ErrorKind:    "connection-refused",
+					ErrorMessage: "request execution failed: dial tcp: connection refused",
+					ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, "request execution failed: dial tcp: connection refused"),
+				},
+			},
+			false,
+			web.QueryResponse{
+				StatusCode: 500,
+				Body: map[string]web.StatementResult{
+					"hero": {
+						Details: web.StatementDetails{Status: 0, Success: false, Metadata: web.StatementMetadata{
+							ErrorKind:    "connection-refused",
+							ErrorMessage: "request execution failed: dial tcp: connection refused",
+						}},
+						Result: rawResult(`"request execution failed: dial tcp: connection refused"`),
+					},
+				},
+				Headers: map[string]string{},
+			},
+		},
 		{
 			"should make response with debugging",
 			domain.Resources{
@@ -94,6 +127,33 @@ func TestMakeQueryResponse(t *testing.T) {
 				},
 			},
 		},
+		{
+			"should make response with hedge outcome on debugging",
+			domain.Resources{
+				"hero": restql.DoneResource{
+					Status:       200,
+					Success:      true,
+					URL:          "http://hero.io/api",
+					Hedged:       true,
+					HedgeWon:     true,
+					ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, test.Unmarshal(`{"id": "12345abcde"}`)),
+				},
+			},
+			true,
+			web.QueryResponse{
+				StatusCode: 200,
+				Body: map[string]web.StatementResult{
+					"hero": {
+						Details: web.StatementDetails{Status: 200, Success: true, Debug: &web.StatementDebugging{
+							URL:   "http://hero.io/api",
+							Hedge: "won",
+						}},
+						Result: rawResult(`{"id": "12345abcde"}`),
+					},
+				},
+				Headers: map[string]string{},
+			},
+		},
 		{
 			"should make response for multiplexed result",
 			domain.Resources{
@@ -343,6 +403,142 @@ func TestMakeQueryResponse(t *testing.T) {
 				Headers: map[string]string{"Cache-Control": "max-age=100, s-maxage=600"},
 			},
 		},
+		{
+			"should make response with most restrictive cache control directives",
+			domain.Resources{
+				"hero": restql.DoneResource{
+					Status:  200,
+					Success: true,
+					CacheControl: restql.ResourceCacheControl{
+						Public:               true,
+						MaxAge:               restql.ResourceCacheControlValue{Exist: true, Time: 400},
+						StaleWhileRevalidate: restql.ResourceCacheControlValue{Exist: true, Time: 60},
+						StaleIfError:         restql.ResourceCacheControlValue{Exist: true, Time: 600},
+					},
+					ResponseBody: &restql.ResponseBody{},
+				},
+				"sidekick": restql.DoneResource{
+					Status:  200,
+					Success: true,
+					CacheControl: restql.ResourceCacheControl{
+						Private:              true,
+						MustRevalidate:       true,
+						MaxAge:               restql.ResourceCacheControlValue{Exist: true, Time: 600},
+						StaleWhileRevalidate: restql.ResourceCacheControlValue{Exist: true, Time: 30},
+					},
+					ResponseBody: &restql.ResponseBody{},
+				},
+				"villain": restql.DoneResource{
+					Status:  200,
+					Success: true,
+					CacheControl: restql.ResourceCacheControl{
+						SMaxAge: restql.ResourceCacheControlValue{Exist: true, Time: 500},
+					},
+					ResponseBody: &restql.ResponseBody{},
+				},
+			},
+			false,
+			web.QueryResponse{
+				StatusCode: 200,
+				Body: map[string]web.StatementResult{
+					"hero": {
+						Details: web.StatementDetails{Status: 200, Success: true},
+						Result:  nil,
+					},
+					"sidekick": {
+						Details: web.StatementDetails{Status: 200, Success: true},
+						Result:  nil,
+					},
+					"villain": {
+						Details: web.StatementDetails{Status: 200, Success: true},
+						Result:  nil,
+					},
+				},
+				Headers: map[string]string{"Cache-Control": "private, max-age=400, s-maxage=500, must-revalidate, stale-while-revalidate=30, stale-if-error=600"},
+			},
+		},
+		{
+			"should make response without expiration when a statement has no cache directive",
+			domain.Resources{
+				"hero": restql.DoneResource{
+					Status:  200,
+					Success: true,
+					CacheControl: restql.ResourceCacheControl{
+						MaxAge:       restql.ResourceCacheControlValue{Exist: true, Time: 600},
+						StaleIfError: restql.ResourceCacheControlValue{Exist: true, Time: 60},
+					},
+					ResponseBody: &restql.ResponseBody{},
+				},
+				"villain": restql.DoneResource{
+					Status:       200,
+					Success:      true,
+					ResponseBody: &restql.ResponseBody{},
+				},
+			},
+			false,
+			web.QueryResponse{
+				StatusCode: 200,
+				Body: map[string]web.StatementResult{
+					"hero": {
+						Details: web.StatementDetails{Status: 200, Success: true},
+						Result:  nil,
+					},
+					"villain": {
+						Details: web.StatementDetails{Status: 200, Success: true},
+						Result:  nil,
+					},
+				},
+				Headers: map[string]string{},
+			},
+		},
+		{
+			"should make response with no-store cache control directive overriding others",
+			domain.Resources{
+				"hero": restql.DoneResource{
+					Status:  200,
+					Success: true,
+					CacheControl: restql.ResourceCacheControl{
+						NoCache: true,
+					},
+					ResponseBody: &restql.ResponseBody{},
+				},
+				"sidekick": restql.DoneResource{
+					Status:  200,
+					Success: true,
+					CacheControl: restql.ResourceCacheControl{
+						NoStore: true,
+					},
+					ResponseBody: &restql.ResponseBody{},
+				},
+				"villain": restql.DoneResource{
+					Status:  200,
+					Success: true,
+					CacheControl: restql.ResourceCacheControl{
+						MaxAge: restql.ResourceCacheControlValue{Exist: true, Time: 400},
+					},
+					ResponseBody: &restql.ResponseBody{},
+				},
+			},
+			false,
+			web.QueryResponse{
+				StatusCode: 200,
+				Body: map[string]web.StatementResult{
+					"hero": {
+						Details: web.StatementDetails{Status: 200, Success: true},
+						Result:  nil,
+					},
+					"sidekick": {
+						Details: web.StatementDetails{Status: 200, Success: true},
+						Result:  nil,
+					},
+					"villain": {
+						Details: web.StatementDetails{Status: 200, Success: true},
+						Result:  nil,
+					},
+				},
+				Headers: map[string]string{"Cache-Control": "no-store"},
+			},
+		},
 		{
 			"should make response with upstream headers",
 			domain.Resources{
@@ -425,7 +621,7 @@ func TestMakeQueryResponse(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, _ := web.MakeQueryResponse(tt.queryResult, tt.debug)
+			got, _ := web.MakeQueryResponse(tt.queryResult, tt.debug, web.StatusCodeOptions{})
 			test.Equal(t, got, tt.expected)
 		})
 	}
@@ -436,6 +632,7 @@ func TestCalculateStatusCode(t *testing.T) {
 	tests := []struct {
 		name        string
 		queryResult domain.Resources
+		options     web.StatusCodeOptions
 		expected    int
 	}{
 		{
@@ -445,6 +642,7 @@ func TestCalculateStatusCode(t *testing.T) {
 				"sidekick": restql.DoneResource{Status: 204},
 				"villain":  restql.DoneResource{Status: 201},
 			},
+			web.StatusCodeOptions{},
 			200,
 		},
 		{
@@ -454,8 +652,18 @@ func TestCalculateStatusCode(t *testing.T) {
 				"sidekick": restql.DoneResource{Status: 500},
 				"villain":  restql.DoneResource{Status: 408},
 			},
+			web.StatusCodeOptions{},
 			500,
 		},
+		{
+			"should return failed statement status code ignoring skipped statements",
+			domain.Resources{
+				"hero":     restql.DoneResource{Status: 404},
+				"sidekick": restql.DoneResource{Status: 0, Skipped: true},
+			},
+			web.StatusCodeOptions{},
+			404,
+		},
 		{
 			"should return max status code",
 			domain.Resources{
@@ -469,6 +677,7 @@ func TestCalculateStatusCode(t *testing.T) {
 				"sidekick": restql.DoneResource{Status: 204},
 				"villain":  restql.DoneResource{Status: 400},
 			},
+			web.StatusCodeOptions{},
 			408,
 		},
 		{
@@ -478,19 +687,432 @@ func TestCalculateStatusCode(t *testing.T) {
 				"sidekick": restql.DoneResource{Status: 500, IgnoreErrors: true},
 				"villain":  restql.DoneResource{Status: 400, IgnoreErrors: true},
 			},
+			web.StatusCodeOptions{},
 			200,
 		},
+		{
+			"should return 200 for status code defined as success",
+			domain.Resources{
+				"hero":    restql.DoneResource{Status: 200, Success: true},
+				"reviews": restql.DoneResource{Status: 404, Success: true},
+			},
+			web.StatusCodeOptions{},
+			200,
+		},
+		{
+			"should return status code of the designated statement",
+			domain.Resources{
+				"product": restql.DoneResource{Status: 404},
+				"reviews": restql.DoneResource{Status: 500},
+			},
+			web.StatusCodeOptions{From: "product"},
+			404,
+		},
+		{
+			"should apply strategy when the designated statement is absent",
+			domain.Resources{
+				"product": restql.DoneResource{Status: 404},
+				"reviews": restql.DoneResource{Status: 500},
+			},
+			web.StatusCodeOptions{From: "price"},
+			500,
+		},
+		{
+			"should return status code of the first failure in query order",
+			domain.Resources{
+				"product": restql.DoneResource{Status: 200},
+				"reviews": restql.DoneResource{Status: 404},
+				"price":   restql.DoneResource{Status: 503},
+			},
+			web.StatusCodeOptions{Strategy: web.StatusStrategyFirstFailure, Order: []domain.ResourceID{"product", "reviews", "price"}},
+			404,
+		},
+		{
+			"should return max status code on first failure strategy without failures",
+			domain.Resources{
+				"product": restql.DoneResource{Status: 200},
+				"reviews": restql.DoneResource{Status: 304},
+			},
+			web.StatusCodeOptions{Strategy: web.StatusStrategyFirstFailure, Order: []domain.ResourceID{"product", "reviews"}},
+			304,
+		},
+		{
+			"should return the most frequent status code",
+			domain.Resources{
+				"product": restql.DoneResource{Status: 200},
+				"reviews": restql.DoneResource{Status: 201},
+				"price":   restql.DoneResource{Status: 503},
+			},
+			web.StatusCodeOptions{Strategy: web.StatusStrategyMajority},
+			200,
+		},
+		{
+			"should return the greater status code on majority ties",
+			domain.Resources{
+				"product": restql.DoneResource{Status: 200},
+				"price":   restql.DoneResource{Status: 503},
+			},
+			web.StatusCodeOptions{Strategy: web.StatusStrategyMajority},
+			503,
+		},
+		{
+			"should always return 200",
+			domain.Resources{
+				"product": restql.DoneResource{Status: 200},
+				"price":   restql.DoneResource{Status: 503},
+			},
+			web.StatusCodeOptions{Strategy: web.StatusStrategyAlways200WithDetails},
+			200,
+		},
+		{
+			"should return status code defined for error kind",
+			domain.Resources{
+				"hero":     restql.DoneResource{Status: 0, ErrorKind: "connection-refused"},
+				"sidekick": restql.DoneResource{Status: 408, ErrorKind: "timeout"},
+				"villain":  restql.DoneResource{Status: 200},
+			},
+			web.StatusCodeOptions{ErrorKindStatus: map[string]int{"connection-refused": 502, "timeout": 504}},
+			504,
+		},
+		{
+			"should return statement status code when error kind is not mapped",
+			domain.Resources{
+				"hero":     restql.DoneResource{Status: 0, ErrorKind: "dns"},
+				"sidekick": restql.DoneResource{Status: 408, ErrorKind: "timeout"},
+			},
+			web.StatusCodeOptions{ErrorKindStatus: map[string]int{"connection-refused": 502}},
+			500,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := web.CalculateStatusCode(tt.queryResult, tt.options)
+
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
+func TestRespondWithETag(t *testing.T) {
+	data := map[string]interface{}{"hero": map[string]interface{}{"id": "12345abcde"}}
+	etag := `"668a2ee3928b495a69b544051973240a4a099565"`
+
+	tests := []struct {
+		name           string
+		ifNoneMatch    string
+		statusCode     int
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			"should respond with body and etag",
+			"",
+			200,
+			200,
+			`{"hero":{"id":"12345abcde"}}` + "\n",
+		},
+		{
+			"should respond not modified when etag matches",
+			etag,
+			200,
+			304,
+			"",
+		},
+		{
+			"should respond not modified when etag matches one of the list",
+			`"abcdef", W/` + etag,
+			200,
+			304,
+			"",
+		},
+		{
+			"should respond not modified for wildcard",
+			"*",
+			200,
+			304,
+			"",
+		},
+		{
+			"should respond with body when etag does not match",
+			`"abcdef"`,
+			200,
+			200,
+			`{"hero":{"id":"12345abcde"}}` + "\n",
+		},
+		{
+			"should respond with body when status is not successful",
+			etag,
+			500,
+			500,
+			`{"hero":{"id":"12345abcde"}}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ctx fasthttp.RequestCtx
+			if tt.ifNoneMatch != "" {
+				ctx.Request.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+
+			err := web.RespondWithETag(&ctx, data, tt.statusCode, map[string]string{"Cache-Control": "max-age=60"})
+			test.VerifyError(t, err)
+
+			test.Equal(t, ctx.Response.StatusCode(), tt.expectedStatus)
+			test.Equal(t, string(ctx.Response.Body()), tt.expectedBody)
+			test.Equal(t, string(ctx.Response.Header.Peek("ETag")), etag)
+			test.Equal(t, string(ctx.Response.Header.Peek("Cache-Control")), "max-age=60")
+		})
+	}
+}
+
+func TestRespondRaw(t *testing.T) {
+	type response struct {
+		Raw         bool
+		Status      int
+		ContentType string
+		Body        string
+	}
+
+	image := restql.DoneResource{
+		Status:          200,
+		Success:         true,
+		ResponseHeaders: map[string]string{"Content-Type": "image/png"},
+		ResponseBody:    restql.NewResponseBodyFromValue(test.NoOpLogger, map[string]interface{}{"contentType": "image/png", "base64": "iVBORw=="}),
+		RawResponseBody: restql.NewResponseBodyFromBytes(test.NoOpLogger, []byte("\x89PNG")),
+	}
+
+	tests := []struct {
+		name     string
+		query    domain.Query
+		result   domain.Resources
+		expected response
+	}{
+		{
+			"should respond upstream body of raw statement",
+			domain.Query{Statements: []domain.Statement{{Method: domain.FromMethod, Resource: "avatar", Raw: true}}},
+			domain.Resources{"avatar": image},
+			response{Raw: true, Status: 200, ContentType: "image/png", Body: "\x89PNG"},
+		},
+		{
+			"should not respond raw without the flag",
+			domain.Query{Statements: []domain.Statement{{Method: domain.FromMethod, Resource: "avatar"}}},
+			domain.Resources{"avatar": image},
+			response{},
+		},
+		{
+			"should not respond raw with more than one statement",
+			domain.Query{Statements: []domain.Statement{{Method: domain.FromMethod, Resource: "avatar", Raw: true}, {Method: domain.FromMethod, Resource: "hero"}}},
+			domain.Resources{"avatar": image, "hero": image},
+			response{},
+		},
+		{
+			"should not respond raw for filtered statement",
+			domain.Query{Statements: []domain.Statement{{Method: domain.FromMethod, Resource: "avatar", Raw: true, Only: []interface{}{[]string{"contentType"}}}}},
+			domain.Resources{"avatar": image},
+			response{},
+		},
+		{
+			"should not respond raw without the upstream body",
+			domain.Query{Statements: []domain.Statement{{Method: domain.FromMethod, Resource: "avatar", Raw: true}}},
+			domain.Resources{"avatar": restql.DoneResource{Status: 200, Success: true, ResponseBody: image.ResponseBody}},
+			response{},
+		},
+		{
+			"should not respond raw for failed request",
+			domain.Query{Statements: []domain.Statement{{Method: domain.FromMethod, Resource: "avatar", Raw: true}}},
+			domain.Resources{"avatar": restql.DoneResource{Status: 408, ErrorKind: domain.ErrorKindTimeout, ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, "timeout")}},
+			response{},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := web.CalculateStatusCode(tt.queryResult)
+			var got response
+
+			dr, ok := web.RawResult(tt.query, tt.result)
+			if ok {
+				var ctx fasthttp.RequestCtx
+				web.RespondRaw(&ctx, dr, nil)
+
+				got = response{
+					Raw:         true,
+					Status:      ctx.Response.StatusCode(),
+					ContentType: string(ctx.Response.Header.ContentType()),
+					Body:        string(ctx.Response.Body()),
+				}
+			}
 
 			test.Equal(t, got, tt.expected)
 		})
 	}
 }
 
+// imageClient answers every request with a PNG image.
+type imageClient struct{}
+
+func (imageClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	return restql.HTTPResponse{
+		StatusCode: 200,
+		Headers:    restql.Headers{"Content-Type": "image/png"},
+		Body:       restql.NewResponseBodyFromBytes(test.NoOpLogger, []byte("\x89PNG\r\n\x1a\n")),
+	}, nil
+}
+
+func TestRespondRawFromExecutor(t *testing.T) {
+	executor := runner.NewExecutor(test.NoOpLogger, imageClient{}, time.Second, "")
+
+	mapping, err := restql.NewMapping("avatar", "http://avatar.io/avatar")
+	test.VerifyError(t, err)
+
+	queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{"avatar": mapping}}
+	ctx := restql.WithLogger(context.Background(), test.NoOpLogger)
+
+	statement := domain.Statement{Method: domain.FromMethod, Resource: "avatar", Raw: true}
+	statement.DependsOn.Resolved = true
+
+	dr := executor.DoStatement(ctx, statement, queryCtx)
+
+	body, err := dr.ResponseBody.Marshal()
+	test.VerifyError(t, err)
+	test.Equal(t, body, rawResult(`{"contentType": "image/png", "base64": "iVBORw0KGgo="}`))
+
+	query := domain.Query{Statements: []domain.Statement{statement}}
+	raw, ok := web.RawResult(query, domain.Resources{domain.NewResourceID(statement): dr})
+	test.Equal(t, ok, true)
+
+	var reqCtx fasthttp.RequestCtx
+	web.RespondRaw(&reqCtx, raw, nil)
+
+	test.Equal(t, reqCtx.Response.StatusCode(), 200)
+	test.Equal(t, string(reqCtx.Response.Header.ContentType()), "image/png")
+	test.Equal(t, reqCtx.Response.Body(), []byte("\x89PNG\r\n\x1a\n"))
+}
+
+// parseFailure mimics the evaluator error for an invalid query.
+type parseFailure struct {
+	error
+}
+
+func (pf parseFailure) Is(target error) bool {
+	return target == eval.ErrParser
+}
+
+func (pf parseFailure) Unwrap() error {
+	return pf.error
+}
+
+func TestRespondError(t *testing.T) {
+	toStatusCode := map[error]int{
+		runner.ErrMaxQueryDenied: 507,
+		eval.ErrParser:           400,
+		eval.ErrMissingVariables: 422,
+	}
+
+	generator, err := ast.New()
+	test.VerifyError(t, err)
+	_, syntaxErr := generator.Parse("from hero with")
+
+	tests := []struct {
+		name                string
+		problemDetails      bool
+		err                 error
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        interface{}
+	}{
+		{
+			"should respond with error message when problem details are disabled",
+			false,
+			fmt.Errorf("%w: query denied", runner.ErrMaxQueryDenied),
+			507,
+			"application/json; charset=utf-8",
+			test.Unmarshal(`{"error": "max concurrent query reached: query execution denied: query denied"}`),
+		},
+		{
+			"should respond with problem details",
+			true,
+			fmt.Errorf("%w: query denied", runner.ErrMaxQueryDenied),
+			507,
+			"application/problem+json",
+			test.Unmarshal(`{
+				"type": "restql/concurrency-denied",
+				"title": "Insufficient Storage",
+				"status": 507,
+				"detail": "max concurrent query reached: query execution denied: query denied",
+				"code": "restql/concurrency-denied",
+				"request-id": "abcdef"
+			}`),
+		},
+		{
+			"should respond with problem details with parsing position",
+			true,
+			parseFailure{syntaxErr},
+			400,
+			"application/problem+json",
+			test.Unmarshal(`{
+				"type": "restql/parse-error",
+				"title": "Bad Request",
+				"status": 400,
+				"detail": "1:15 (14): no match found, expected: \"//\", \"\\n\" or [ \\t]",
+				"code": "restql/parse-error",
+				"request-id": "abcdef",
+				"position": {"line": 1, "column": 15, "offset": 14},
+				"expected": ["\"//\"", "\"\\n\"", "[ \\t]"]
+			}`),
+		},
+		{
+			"should respond with problem details with missing variables",
+			true,
+			eval.MissingVariablesError{Variables: []string{"id", "universe"}},
+			422,
+			"application/problem+json",
+			test.Unmarshal(`{
+				"type": "restql/missing-variables",
+				"title": "Unprocessable Entity",
+				"status": 422,
+				"detail": "missing variables: id, universe",
+				"code": "restql/missing-variables",
+				"request-id": "abcdef",
+				"missingVariables": ["id", "universe"]
+			}`),
+		},
+		{
+			"should respond with internal error code for unknown errors",
+			true,
+			fmt.Errorf("some failure"),
+			500,
+			"application/problem+json",
+			test.Unmarshal(`{
+				"type": "restql/internal-error",
+				"title": "Internal Server Error",
+				"status": 500,
+				"detail": "some failure",
+				"code": "restql/internal-error",
+				"request-id": "abcdef"
+			}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ctx fasthttp.RequestCtx
+			ctx.Request.Header.Set("X-TID", "abcdef")
+			if tt.problemDetails {
+				web.EnableProblemDetails(&ctx, "X-TID")
+			}
+
+			err := web.RespondError(&ctx, tt.err, toStatusCode)
+			test.VerifyError(t, err)
+
+			test.Equal(t, ctx.Response.StatusCode(), tt.expectedStatus)
+			test.Equal(t, string(ctx.Response.Header.ContentType()), tt.expectedContentType)
+			test.Equal(t, test.Unmarshal(string(ctx.Response.Body())), tt.expectedBody)
+		})
+	}
+}
+
 func rawResult(s string) json.RawMessage {
 	b, err := json.Marshal(test.Unmarshal(s))
 	if err != nil {
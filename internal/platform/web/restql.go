@@ -6,8 +6,10 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v6/internal/eval"
 	"github.com/b2wdigital/restQL-golang/v6/internal/parser"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/cache"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web/middleware"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
@@ -24,14 +26,15 @@ var (
 )
 
 type restQl struct {
-	config    *conf.Config
-	log       restql.Logger
-	evaluator eval.Evaluator
-	parser    parser.Parser
+	config        *conf.Config
+	log           restql.Logger
+	evaluator     eval.Evaluator
+	parser        parser.Parser
+	responseCache *cache.ResponseCache
 }
 
-func newRestQl(l restql.Logger, cfg *conf.Config, e eval.Evaluator, p parser.Parser) restQl {
-	return restQl{config: cfg, log: l, evaluator: e, parser: p}
+func newRestQl(l restql.Logger, cfg *conf.Config, e eval.Evaluator, p parser.Parser, rc *cache.ResponseCache) restQl {
+	return restQl{config: cfg, log: l, evaluator: e, parser: p, responseCache: rc}
 }
 
 func (r restQl) ValidateQuery(ctx *fasthttp.RequestCtx) error {
@@ -39,9 +42,8 @@ func (r restQl) ValidateQuery(ctx *fasthttp.RequestCtx) error {
 	_, err := r.parser.Parse(queryTxt)
 	if err != nil {
 		r.log.Error("an error occurred when parsing query", err)
-		e := fmt.Errorf("%w: %s", parser.ErrInvalidQuery, err)
 
-		return RespondError(ctx, e, errToStatusCode)
+		return RespondError(ctx, invalidQueryError{err: err}, errToStatusCode)
 	}
 
 	return Respond(ctx, nil, http.StatusOK, nil)
@@ -65,7 +67,7 @@ func (r restQl) RunAdHocQuery(reqCtx *fasthttp.RequestCtx) error {
 
 	queryTxt := string(reqCtx.PostBody())
 
-	result, err := r.evaluator.AdHocQuery(ctx, queryTxt, options, input)
+	query, result, err := r.evaluator.AdHocQuery(ctx, queryTxt, options, input)
 	if err != nil {
 		r.log.Error("failed to evaluated adhoc query", err)
 
@@ -78,13 +80,22 @@ func (r restQl) RunAdHocQuery(reqCtx *fasthttp.RequestCtx) error {
 		return RespondError(reqCtx, err, adhocErrToStatusCode)
 	}
 
+	surrogateKeys := r.makeSurrogateKeyHeaders(result)
+	result = eval.ApplyHidden(query, result)
+
 	debugEnabled := isDebugEnabled(r.config, input)
-	response, err := MakeQueryResponse(result, debugEnabled)
+	if dr, ok := RawResult(query, result); ok && !debugEnabled {
+		RespondRaw(reqCtx, dr, appendMap(makeCacheControlHeaders(result), surrogateKeys))
+		return nil
+	}
+
+	response, err := MakeQueryResponse(result, debugEnabled, r.statusCodeOptions(query, options))
 	if err != nil {
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
+	response.Headers = appendMap(response.Headers, surrogateKeys)
 
-	return Respond(reqCtx, response.Body, response.StatusCode, response.Headers)
+	return RespondWithETag(reqCtx, response.Body, response.StatusCode, response.Headers)
 }
 
 func (r restQl) RunSavedQuery(reqCtx *fasthttp.RequestCtx) error {
@@ -106,20 +117,70 @@ func (r restQl) RunSavedQuery(reqCtx *fasthttp.RequestCtx) error {
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
-	result, err := r.evaluator.SavedQuery(ctx, options, input)
+	debugEnabled := isDebugEnabled(r.config, input)
+	cacheEnabled := r.responseCache != nil && !debugEnabled
+
+	var cacheKey string
+	if cacheEnabled {
+		cacheKey = MakeResponseCacheKey(options, input, ResponseCacheKeyOptions{
+			Params:  r.config.Cache.Response.KeyParams,
+			Headers: r.config.Cache.Response.KeyHeaders,
+		})
+
+		if cached, age, found := r.responseCache.Get(cacheKey); found {
+			if response, ok := cached.(QueryResponse); ok {
+				log.Debug("saved query response found in cache", "key", cacheKey)
+				headers := withCacheHeaders(response.Headers, cacheHit, age)
+				return RespondWithETag(reqCtx, response.Body, response.StatusCode, headers)
+			}
+		}
+	}
+
+	query, result, err := r.evaluator.SavedQuery(ctx, options, input)
 	if err != nil {
 		log.Error("failed to evaluated saved query", err)
 
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
-	debugEnabled := isDebugEnabled(r.config, input)
-	response, err := MakeQueryResponse(result, debugEnabled)
+	surrogateKeys := r.makeSurrogateKeyHeaders(result)
+	cacheable := IsCacheableResponse(query, result)
+	result = eval.ApplyHidden(query, result)
+
+	if dr, ok := RawResult(query, result); ok && !debugEnabled {
+		RespondRaw(reqCtx, dr, appendMap(makeCacheControlHeaders(result), surrogateKeys))
+		return nil
+	}
+
+	response, err := MakeQueryResponse(result, debugEnabled, r.statusCodeOptions(query, options))
 	if err != nil {
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
+	response.Headers = appendMap(response.Headers, surrogateKeys)
 
-	return Respond(reqCtx, response.Body, response.StatusCode, response.Headers)
+	if cacheEnabled {
+		ttl, ok := CalculateResponseCacheTTL(result)
+		if ok && cacheable {
+			r.responseCache.Set(cacheKey, response, ttl)
+		}
+
+		response.Headers = withCacheHeaders(response.Headers, cacheMiss, 0)
+	}
+
+	return RespondWithETag(reqCtx, response.Body, response.StatusCode, response.Headers)
+}
+
+// makeSurrogateKeyHeaders builds the surrogate keys from the whole
+// query result, since hidden statements also shape the response
+// through the values they provide to chained statements.
+func (r restQl) makeSurrogateKeyHeaders(queryResult domain.Resources) map[string]string {
+	cfg := r.config.Cache.SurrogateKey
+	if !cfg.Enable {
+		return nil
+	}
+
+	options := SurrogateKeyOptions{Header: cfg.Header, Separator: cfg.Separator, IncludeIDs: cfg.IncludeIDs}
+	return makeSurrogateKeyHeaders(options, queryResult)
 }
 
 func makeQueryOptions(ctx *fasthttp.RequestCtx, log restql.Logger, envTenant string) (restql.QueryOptions, error) {
@@ -276,3 +337,61 @@ func extractDebugFromQuery(queryInput restql.QueryInput) bool {
 
 	return d
 }
+
+// statusCodeOptions uses the status strategy defined by the
+// query `use` clause, or the one configured for the saved
+// query, its namespace or all queries, in this order.
+func (r restQl) statusCodeOptions(query domain.Query, options restql.QueryOptions) StatusCodeOptions {
+	order := make([]domain.ResourceID, len(query.Statements))
+	for i, stmt := range query.Statements {
+		order[i] = domain.NewResourceID(stmt)
+	}
+
+	so := StatusCodeOptions{
+		ErrorKindStatus: r.config.HTTP.ErrorKindStatus,
+		Strategy:        r.configuredStatusStrategy(options),
+		Order:           order,
+	}
+
+	if strategy, ok := query.Use["status-strategy"].(string); ok {
+		so.Strategy = strategy
+	}
+
+	if from, ok := query.Use["status-from"].(string); ok {
+		so.From = domain.ResourceID(from)
+	}
+
+	return so
+}
+
+func (r restQl) configuredStatusStrategy(options restql.QueryOptions) string {
+	cfg := r.config.HTTP.StatusStrategy
+
+	if strategy, found := cfg.Queries[options.Namespace+"/"+options.Id]; found && options.Id != "" {
+		return strategy
+	}
+
+	if strategy, found := cfg.Namespaces[options.Namespace]; found && options.Namespace != "" {
+		return strategy
+	}
+
+	return cfg.Default
+}
+
+// invalidQueryError identifies a parsing failure as
+// parser.ErrInvalidQuery while keeping its cause reachable.
+type invalidQueryError struct {
+	err error
+}
+
+func (ie invalidQueryError) Error() string {
+	return fmt.Sprintf("%s: %s", parser.ErrInvalidQuery, ie.err)
+}
+
+func (ie invalidQueryError) Is(target error) bool {
+	return target == parser.ErrInvalidQuery
+}
+
+func (ie invalidQueryError) Unwrap() error {
+	return ie.err
+}
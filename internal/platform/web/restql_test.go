@@ -0,0 +1,220 @@
+package web
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/eval"
+	"github.com/b2wdigital/restQL-golang/v6/internal/parser"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/cache"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web/middleware"
+	"github.com/b2wdigital/restQL-golang/v6/internal/runner"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/valyala/fasthttp"
+)
+
+type stubMappingsReader map[string]restql.Mapping
+
+func (s stubMappingsReader) FromTenant(ctx context.Context, tenant string) (map[string]restql.Mapping, error) {
+	return s, nil
+}
+
+type stubQueryReader map[string]string
+
+func (s stubQueryReader) Get(ctx context.Context, namespace, id string, revision int) (restql.SavedQueryRevision, error) {
+	return restql.SavedQueryRevision{Name: id, Text: s[id], Revision: revision}, nil
+}
+
+// stubClient answers each request with the response defined for its host.
+type stubClient map[string]restql.HTTPResponse
+
+func (s stubClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	return s[request.Host], nil
+}
+
+func jsonResponse(status int, body string) restql.HTTPResponse {
+	return restql.HTTPResponse{
+		StatusCode: status,
+		Headers:    restql.Headers{"Content-Type": "application/json"},
+		Body:       restql.NewResponseBodyFromBytes(test.NoOpLogger, []byte(body)),
+	}
+}
+
+func newTestRestQl(t *testing.T, cfg *conf.Config, client stubClient, mappings map[string]string, queries map[string]string) restQl {
+	mr := make(stubMappingsReader)
+	for name, url := range mappings {
+		m, err := restql.NewMapping(name, url)
+		test.VerifyError(t, err)
+		mr[name] = m
+	}
+
+	p, err := parser.New()
+	test.VerifyError(t, err)
+
+	executor := runner.NewExecutor(test.NoOpLogger, client, time.Second, "")
+	r := runner.NewRunner(test.NoOpLogger, executor, runner.Options{GlobalQueryTimeout: time.Second})
+	e := eval.NewEvaluator(test.NoOpLogger, mr, stubQueryReader(queries), r, p, plugins.NoOpLifecycle)
+
+	return newRestQl(test.NoOpLogger, cfg, e, p, nil)
+}
+
+func TestRunAdHocQuerySurrogateKeysOfHiddenStatements(t *testing.T) {
+	cfg := &conf.Config{}
+	cfg.Cache.SurrogateKey.Enable = true
+	cfg.Cache.SurrogateKey.Header = "Surrogate-Key"
+	cfg.Cache.SurrogateKey.Separator = " "
+
+	client := stubClient{
+		"product.io": jsonResponse(200, `{"id": "123"}`),
+		"price.io":   jsonResponse(200, `{"value": 10}`),
+	}
+	mappings := map[string]string{"product": "http://product.io/product", "price": "http://price.io/price"}
+	r := newTestRestQl(t, cfg, client, mappings, nil)
+
+	var req fasthttp.Request
+	req.SetRequestURI("/run-query?tenant=default")
+	req.SetBodyString("from product as p hidden\nfrom price with id = p.id")
+
+	var ctx fasthttp.RequestCtx
+	ctx.Init(&req, nil, nil)
+
+	err := r.RunAdHocQuery(&ctx)
+	test.VerifyError(t, err)
+
+	test.Equal(t, ctx.Response.StatusCode(), 200)
+	test.Equal(t, string(ctx.Response.Header.Peek("Surrogate-Key")), "price product")
+	test.Equal(t, test.Unmarshal(string(ctx.Response.Body())), test.Unmarshal(`{
+		"price": {"details": {"status": 200, "success": true, "metadata": {}}, "result": {"value": 10}}
+	}`))
+}
+
+func TestRunAdHocQueryRawStatement(t *testing.T) {
+	tests := []struct {
+		name                 string
+		uri                  string
+		query                string
+		expectedContentType  string
+		expectedBody         string
+		expectedSurrogateKey string
+	}{
+		{
+			"should stream upstream body with surrogate keys",
+			"/run-query?tenant=default",
+			"from avatar raw",
+			"image/png",
+			"\x89PNG\r\n\x1a\n",
+			"avatar",
+		},
+		{
+			"should encode upstream body as base64 when debug is enabled",
+			"/run-query?tenant=default&_debug=true",
+			"from avatar raw",
+			"application/json; charset=utf-8",
+			`"base64":"iVBORw0KGgo="`,
+			"avatar",
+		},
+		{
+			"should encode upstream body as base64 with more than one statement",
+			"/run-query?tenant=default",
+			"from avatar raw\nfrom price",
+			"application/json; charset=utf-8",
+			`"base64":"iVBORw0KGgo="`,
+			"avatar price",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &conf.Config{}
+			cfg.Debugging.QueryParam = true
+			cfg.Cache.SurrogateKey.Enable = true
+			cfg.Cache.SurrogateKey.Header = "Surrogate-Key"
+			cfg.Cache.SurrogateKey.Separator = " "
+
+			client := stubClient{
+				"avatar.io": {
+					StatusCode: 200,
+					Headers:    restql.Headers{"Content-Type": "image/png"},
+					Body:       restql.NewResponseBodyFromBytes(test.NoOpLogger, []byte("\x89PNG\r\n\x1a\n")),
+				},
+				"price.io": jsonResponse(200, `{"value": 10}`),
+			}
+			mappings := map[string]string{"avatar": "http://avatar.io/avatar", "price": "http://price.io/price"}
+			r := newTestRestQl(t, cfg, client, mappings, nil)
+
+			var req fasthttp.Request
+			req.SetRequestURI(tt.uri)
+			req.SetBodyString(tt.query)
+
+			var ctx fasthttp.RequestCtx
+			ctx.Init(&req, nil, nil)
+
+			err := r.RunAdHocQuery(&ctx)
+			test.VerifyError(t, err)
+
+			test.Equal(t, ctx.Response.StatusCode(), 200)
+			test.Equal(t, string(ctx.Response.Header.ContentType()), tt.expectedContentType)
+			test.Equal(t, string(ctx.Response.Header.Peek("Surrogate-Key")), tt.expectedSurrogateKey)
+			test.Equal(t, strings.Contains(string(ctx.Response.Body()), tt.expectedBody), true)
+		})
+	}
+}
+
+func TestRunSavedQueryResponseCacheOfHiddenStatements(t *testing.T) {
+	tests := []struct {
+		name            string
+		productResponse restql.HTTPResponse
+		expected        string
+	}{
+		{
+			"should cache response when hidden statement succeeds",
+			jsonResponse(200, `{"id": "123"}`),
+			cacheHit,
+		},
+		{
+			"should not cache response when hidden statement fails",
+			jsonResponse(500, `{"error": "unavailable"}`),
+			cacheMiss,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &conf.Config{}
+			cfg.Cache.Response.Enable = true
+			cfg.Cache.Response.MaxSize = 10
+
+			client := stubClient{
+				"product.io": tt.productResponse,
+				"price.io":   jsonResponse(200, `{"value": 10}`),
+			}
+			mappings := map[string]string{"product": "http://product.io/product", "price": "http://price.io/price"}
+			queries := map[string]string{"prices": "use max-age 60\nfrom product hidden\nfrom price"}
+			r := newTestRestQl(t, cfg, client, mappings, queries)
+			r.responseCache = cache.NewResponseCache(test.NoOpLogger, cfg.Cache.Response.MaxSize)
+
+			var ctx fasthttp.RequestCtx
+			for i := 0; i < 2; i++ {
+				var req fasthttp.Request
+				req.SetRequestURI("/run-query/catalog/prices/1?tenant=default")
+
+				ctx = fasthttp.RequestCtx{}
+				ctx.Init(&req, nil, nil)
+				middleware.WithNativeContext(&ctx, context.Background())
+				ctx.SetUserValue("namespace", "catalog")
+				ctx.SetUserValue("queryId", "prices")
+				ctx.SetUserValue("revision", "1")
+
+				err := r.RunSavedQuery(&ctx)
+				test.VerifyError(t, err)
+			}
+
+			test.Equal(t, string(ctx.Response.Header.Peek(cacheStatusHeader)), tt.expected)
+		})
+	}
+}
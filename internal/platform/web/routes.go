@@ -16,13 +16,19 @@ import (
 	"github.com/valyala/fasthttp"
 )
 
+// LimiterStatusReporter provides the current state of the query limiter.
+type LimiterStatusReporter interface {
+	LimiterStatus() runner.LimiterStatus
+}
+
 // API constructs a handler for the restQL query related endpoints
-func API(log restql.Logger, cfg *conf.Config) (fasthttp.RequestHandler, error) {
+// and returns the reporter of its query limiter status.
+func API(log restql.Logger, cfg *conf.Config) (fasthttp.RequestHandler, LimiterStatusReporter, error) {
 	log.Debug("starting api")
 	defaultParser, err := parser.New()
 	if err != nil {
 		log.Error("failed to compile parser", err)
-		return nil, err
+		return nil, nil, err
 	}
 	parserCacheLoader := cache.New(log, cfg.Cache.Parser.MaxSize, cache.ParserCacheLoader(defaultParser))
 	parserCache := cache.NewParserCache(log, parserCacheLoader)
@@ -31,7 +37,7 @@ func API(log restql.Logger, cfg *conf.Config) (fasthttp.RequestHandler, error) {
 	db, err := persistence.NewDatabase(log, databaseDisabled)
 	if err != nil {
 		log.Error("failed to establish connection to database", err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	lifecycle, err := plugins.NewLifecycle(log)
@@ -40,14 +46,52 @@ func API(log restql.Logger, cfg *conf.Config) (fasthttp.RequestHandler, error) {
 	}
 
 	client := httpclient.New(log, lifecycle, cfg)
-	executor := runner.NewExecutor(log, client, cfg.HTTP.QueryResourceTimeout, cfg.HTTP.ForwardPrefix)
+	var executorOptions []runner.ExecutorOption
+	if header := cfg.HTTP.DeadlinePropagation.Header; header != "" {
+		log.Info("deadline propagation enabled", "header", header, "format", cfg.HTTP.DeadlinePropagation.Format)
+		executorOptions = append(executorOptions, runner.WithDeadlineHeader(header, cfg.HTTP.DeadlinePropagation.Format))
+	}
+	if len(cfg.HTTP.Hedging) > 0 {
+		log.Info("request hedging enabled", "resources", cfg.HTTP.Hedging)
+		executorOptions = append(executorOptions, runner.WithHedgeDelays(cfg.HTTP.Hedging))
+	}
+	executorOptions = append(executorOptions, runner.WithLoadBalancing(runner.LoadBalancing{
+		Strategy:          cfg.HTTP.LoadBalancing.Strategy,
+		Resources:         cfg.HTTP.LoadBalancing.Resources,
+		EjectionThreshold: cfg.HTTP.LoadBalancing.Ejection.ConsecutiveFailures,
+		EjectionDuration:  cfg.HTTP.LoadBalancing.Ejection.Duration,
+	}))
+	if cfg.HTTP.Client.Compression {
+		log.Info("upstream response compression enabled")
+		executorOptions = append(executorOptions, runner.WithCompression(true))
+	}
+	if len(cfg.HTTP.StatusDefaults) > 0 {
+		executorOptions = append(executorOptions, runner.WithStatusDefaults(makeStatusDefaults(cfg)))
+	}
+
+	executor := runner.NewExecutor(log, client, cfg.HTTP.QueryResourceTimeout, cfg.HTTP.ForwardPrefix, executorOptions...)
+	tenantQuotas, namespaceQuotas := makeRunnerQuotas(cfg)
 	r := runner.NewRunner(log, executor, runner.Options{
 		GlobalQueryTimeout:      cfg.HTTP.GlobalQueryTimeout,
+		FailFast:                cfg.HTTP.FailFast,
 		MaxConcurrentQueries:    cfg.HTTP.Client.MaxConcurrentQueries,
 		MaxConcurrentGoroutines: cfg.HTTP.Client.MaxConcurrentGoroutines,
+		WorkerPoolSize:          cfg.HTTP.Client.WorkerPoolSize,
+		MaxQueueWait:            cfg.HTTP.Client.Queue.MaxWait,
+		MaxQueueLength:          cfg.HTTP.Client.Queue.MaxLength,
+		TenantQuotas:            tenantQuotas,
+		NamespaceQuotas:         namespaceQuotas,
+		AdaptiveLimit: runner.AdaptiveLimitOptions{
+			Enable:           cfg.HTTP.Client.AdaptiveConcurrency.Enable,
+			InitialLimit:     cfg.HTTP.Client.AdaptiveConcurrency.InitialLimit,
+			MinLimit:         cfg.HTTP.Client.AdaptiveConcurrency.MinLimit,
+			MaxLimit:         cfg.HTTP.Client.AdaptiveConcurrency.MaxLimit,
+			LatencyThreshold: cfg.HTTP.Client.AdaptiveConcurrency.LatencyThreshold,
+			BackoffRatio:     cfg.HTTP.Client.AdaptiveConcurrency.BackoffRatio,
+		},
 	})
 
-	mappingReader := persistence.NewMappingReader(log, cfg.Env, cfg.TenantMappings, db)
+	mappingReader := persistence.NewMappingReader(log, cfg.Env, makeTenantMappings(cfg), db)
 	cacheMr := addMappingsReaderCache(log, cfg, mappingReader)
 
 	queryReader := persistence.NewQueryReader(log, cfg.Queries, db)
@@ -55,10 +99,16 @@ func API(log restql.Logger, cfg *conf.Config) (fasthttp.RequestHandler, error) {
 
 	e := eval.NewEvaluator(log, cacheMr, cacheQr, r, parserCache, lifecycle)
 
-	restQl := newRestQl(log, cfg, e, defaultParser)
+	responseCache := addResponseCache(log, cfg)
+
+	restQl := newRestQl(log, cfg, e, defaultParser, responseCache)
 
 	md := middleware.NewDecorator(log, cfg, lifecycle)
-	app := newApp(log, appOptions{MiddlewareDecorator: md})
+	app := newApp(log, appOptions{
+		MiddlewareDecorator: md,
+		ProblemDetails:      cfg.HTTP.Server.ProblemDetails,
+		RequestIDHeader:     cfg.HTTP.Server.Middlewares.RequestID.Header,
+	})
 	app.Handle(http.MethodPost, "/validate-query", restQl.ValidateQuery)
 	app.Handle(http.MethodPost, "/run-query", restQl.RunAdHocQuery)
 	app.Handle(http.MethodGet, "/run-query/{namespace}/{queryId}/{revision}", restQl.RunSavedQuery)
@@ -66,14 +116,49 @@ func API(log restql.Logger, cfg *conf.Config) (fasthttp.RequestHandler, error) {
 
 	if cfg.HTTP.Server.Admin.Enable {
 		log.Info("administration api enabled")
-		mw := persistence.NewMappingWriter(log, cfg.Env, cfg.TenantMappings, db)
+		mw := persistence.NewMappingWriter(log, cfg.Env, makeTenantMappings(cfg), db)
 		qw := persistence.NewQueryWriter(log, cfg.Queries, db)
 
 		adm := newAdmin(log, mappingReader, mw, queryReader, qw, cfg.HTTP.Server.Admin.AuthorizationCode)
 		app = registerAdminEndpoints(adm, app)
 	}
 
-	return app.RequestHandler(), nil
+	return app.RequestHandler(), r, nil
+}
+
+func makeRunnerQuotas(cfg *conf.Config) (tenants map[string]runner.Quota, namespaces map[string]runner.Quota) {
+	tenants = make(map[string]runner.Quota)
+	for tenant, q := range cfg.HTTP.Client.Quotas.Tenants {
+		tenants[tenant] = runner.Quota{MaxConcurrentQueries: q.MaxConcurrentQueries, MaxConcurrentGoroutines: q.MaxConcurrentGoroutines}
+	}
+
+	namespaces = make(map[string]runner.Quota)
+	for namespace, q := range cfg.HTTP.Client.Quotas.Namespaces {
+		namespaces[namespace] = runner.Quota{MaxConcurrentQueries: q.MaxConcurrentQueries, MaxConcurrentGoroutines: q.MaxConcurrentGoroutines}
+	}
+
+	return tenants, namespaces
+}
+
+func makeTenantMappings(cfg *conf.Config) map[string]map[string]string {
+	tenants := make(map[string]map[string]string)
+	for tenant, mappings := range cfg.TenantMappings {
+		tenants[tenant] = make(map[string]string)
+		for resource, value := range mappings {
+			tenants[tenant][resource] = string(value)
+		}
+	}
+
+	return tenants
+}
+
+func makeStatusDefaults(cfg *conf.Config) map[string]runner.StatusDefaults {
+	defaults := make(map[string]runner.StatusDefaults)
+	for resource, d := range cfg.HTTP.StatusDefaults {
+		defaults[resource] = runner.StatusDefaults{IgnoreErrors: d.IgnoreErrors, SuccessOn: d.SuccessOn}
+	}
+
+	return defaults
 }
 
 func addMappingsReaderCache(log restql.Logger, cfg *conf.Config, mappingReader persistence.MappingsReader) eval.MappingsReader {
@@ -105,6 +190,16 @@ func addQueryReaderCache(log restql.Logger, cfg *conf.Config, queryReader persis
 	return cacheQr
 }
 
+func addResponseCache(log restql.Logger, cfg *conf.Config) *cache.ResponseCache {
+	if cfg.Cache.Disable || !cfg.Cache.Response.Enable {
+		return nil
+	}
+
+	log.Info("response cache enabled")
+
+	return cache.NewResponseCache(log, cfg.Cache.Response.MaxSize)
+}
+
 // registerAdminEndpoints adds handlers for administrative operations
 func registerAdminEndpoints(adm *administrator, apiApp app) app {
 	apiApp.Handle(http.MethodGet, "/admin/tenant", adm.AllTenants)
@@ -124,12 +219,13 @@ func registerAdminEndpoints(adm *administrator, apiApp app) app {
 }
 
 // Health constructs a handler for system checks endpoints
-func Health(log restql.Logger, cfg *conf.Config) fasthttp.RequestHandler {
+func Health(log restql.Logger, cfg *conf.Config, limiter LimiterStatusReporter) fasthttp.RequestHandler {
 	app := newApp(log, appOptions{})
-	check := newCheck(cfg.Build)
+	check := newCheck(cfg.Build, limiter)
 
 	app.Handle(http.MethodGet, "/health", check.Health)
 	app.Handle(http.MethodGet, "/resource-status", check.ResourceStatus)
+	app.Handle(http.MethodGet, "/concurrency-limit", check.ConcurrencyLimit)
 
 	return app.RequestHandler()
 }
@@ -0,0 +1,75 @@
+package web
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+)
+
+// SurrogateKeyOptions defines how the surrogate keys
+// of a query response are built and sent to the client.
+type SurrogateKeyOptions struct {
+	Header     string
+	Separator  string
+	IncludeIDs bool
+}
+
+// MakeSurrogateKeys returns the sorted set of keys identifying
+// every upstream resource that contributed to the query result.
+// Each resource is identified by its mapping name and, if enabled,
+// by its mapping name followed by each path parameter value,
+// like `product/123`.
+func MakeSurrogateKeys(queryResult domain.Resources, includeIDs bool) []string {
+	keys := make(map[string]struct{})
+	for _, r := range queryResult {
+		collectSurrogateKeys(keys, r, includeIDs)
+	}
+
+	result := make([]string, 0, len(keys))
+	for k := range keys {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+func collectSurrogateKeys(keys map[string]struct{}, result interface{}, includeIDs bool) {
+	switch r := result.(type) {
+	case restql.DoneResource:
+		if r.ResourceName == "" {
+			return
+		}
+
+		keys[r.ResourceName] = struct{}{}
+
+		if !includeIDs {
+			return
+		}
+
+		for _, value := range r.RequestPathParams {
+			id := fmt.Sprintf("%v", value)
+			if id == "" {
+				continue
+			}
+
+			keys[r.ResourceName+"/"+id] = struct{}{}
+		}
+	case restql.DoneResources:
+		for _, dr := range r {
+			collectSurrogateKeys(keys, dr, includeIDs)
+		}
+	}
+}
+
+func makeSurrogateKeyHeaders(options SurrogateKeyOptions, queryResult domain.Resources) map[string]string {
+	keys := MakeSurrogateKeys(queryResult, options.IncludeIDs)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return map[string]string{options.Header: strings.Join(keys, options.Separator)}
+}
@@ -0,0 +1,49 @@
+package web_test
+
+import (
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestMakeSurrogateKeys(t *testing.T) {
+	queryResult := domain.Resources{
+		"hero": restql.DoneResource{
+			ResourceName:      "hero",
+			RequestPathParams: map[string]interface{}{"id": 1},
+		},
+		"products": restql.DoneResources{
+			restql.DoneResource{ResourceName: "product", RequestPathParams: map[string]interface{}{"id": "123"}},
+			restql.DoneResource{ResourceName: "product", RequestPathParams: map[string]interface{}{"id": "456"}},
+		},
+		"sidekick": restql.DoneResource{ResourceName: "sidekick"},
+		"villain":  restql.DoneResource{Status: 400},
+	}
+
+	tests := []struct {
+		name       string
+		includeIDs bool
+		expected   []string
+	}{
+		{
+			"should make keys from resources names",
+			false,
+			[]string{"hero", "product", "sidekick"},
+		},
+		{
+			"should make keys from resources names and path params",
+			true,
+			[]string{"hero", "hero/1", "product", "product/123", "product/456", "sidekick"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := web.MakeSurrogateKeys(queryResult, tt.includeIDs)
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
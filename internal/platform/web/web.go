@@ -9,6 +9,8 @@ import (
 
 type appOptions struct {
 	MiddlewareDecorator *middleware.Decorator
+	ProblemDetails      bool
+	RequestIDHeader     string
 }
 
 type handler func(ctx *fasthttp.RequestCtx) error
@@ -28,6 +30,10 @@ func newApp(log restql.Logger, o appOptions) app {
 
 func (a app) Handle(method, url string, handler handler) {
 	fn := func(ctx *fasthttp.RequestCtx) {
+		if a.options.ProblemDetails {
+			EnableProblemDetails(ctx, a.options.RequestIDHeader)
+		}
+
 		err := handler(ctx)
 
 		if err != nil {
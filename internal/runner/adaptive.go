@@ -0,0 +1,107 @@
+package runner
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const defaultBackoffRatio = 0.9
+
+// AdaptiveLimitOptions configures the adaptive query limiter.
+type AdaptiveLimitOptions struct {
+	Enable           bool
+	InitialLimit     int
+	MinLimit         int
+	MaxLimit         int
+	LatencyThreshold time.Duration
+	BackoffRatio     float64
+}
+
+// adaptiveLimit adjusts the limit of a limiter based on the observed
+// query latency, following the additive increase, multiplicative
+// decrease (AIMD) algorithm. Each query that finishes within the
+// latency threshold raises the limit by 1/limit, hence by one for each
+// full window of successful queries, while a query that exceeds the
+// threshold or times out multiplies it by the backoff ratio.
+type adaptiveLimit struct {
+	mu      sync.Mutex
+	limiter *limiter
+
+	minLimit         float64
+	maxLimit         float64
+	latencyThreshold time.Duration
+	backoffRatio     float64
+
+	limit        float64
+	lastDecrease time.Time
+}
+
+func newAdaptiveLimit(options AdaptiveLimitOptions, maxQueueLength int) *adaptiveLimit {
+	minLimit := options.MinLimit
+	if minLimit < 1 {
+		minLimit = 1
+	}
+
+	maxLimit := options.MaxLimit
+	if maxLimit <= 0 {
+		maxLimit = math.MaxInt32
+	}
+	if maxLimit < minLimit {
+		maxLimit = minLimit
+	}
+
+	initialLimit := options.InitialLimit
+	switch {
+	case initialLimit < minLimit:
+		initialLimit = minLimit
+	case initialLimit > maxLimit:
+		initialLimit = maxLimit
+	}
+
+	backoffRatio := options.BackoffRatio
+	if backoffRatio <= 0 || backoffRatio >= 1 {
+		backoffRatio = defaultBackoffRatio
+	}
+
+	return &adaptiveLimit{
+		limiter:          newQueueingLimiter(int32(initialLimit), maxQueueLength),
+		minLimit:         float64(minLimit),
+		maxLimit:         float64(maxLimit),
+		latencyThreshold: options.LatencyThreshold,
+		backoffRatio:     backoffRatio,
+		limit:            float64(initialLimit),
+	}
+}
+
+// Observe updates the limit with the outcome of a query started
+// at the given time. Errors other than a timeout are not related
+// to latency and are ignored. Once the limit is decreased, slow
+// queries started before it are ignored, since they were admitted
+// under the previous limit.
+func (a *adaptiveLimit) Observe(start time.Time, err error) {
+	if err != nil && !errors.Is(err, ErrQueryTimedOut) {
+		return
+	}
+
+	latency := time.Since(start)
+	overloaded := err != nil || (a.latencyThreshold > 0 && latency > a.latencyThreshold)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if overloaded {
+		if start.Before(a.lastDecrease) {
+			return
+		}
+
+		a.limit = math.Max(a.minLimit, a.limit*a.backoffRatio)
+		a.lastDecrease = time.Now()
+	} else {
+		a.limit = math.Min(a.maxLimit, a.limit+1/a.limit)
+	}
+
+	a.limiter.SetLimit(int32(a.limit))
+}
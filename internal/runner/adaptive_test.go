@@ -0,0 +1,74 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestAdaptiveLimit(t *testing.T) {
+	a := newAdaptiveLimit(AdaptiveLimitOptions{
+		InitialLimit:     10,
+		MinLimit:         5,
+		MaxLimit:         11,
+		LatencyThreshold: time.Second,
+		BackoffRatio:     0.5,
+	}, 0)
+
+	for i := 0; i < 10; i++ {
+		a.Observe(time.Now(), nil)
+	}
+	test.Equal(t, limitOf(a), 10)
+
+	for i := 0; i < 20; i++ {
+		a.Observe(time.Now(), nil)
+	}
+	test.Equal(t, limitOf(a), 11)
+
+	slowStart := time.Now().Add(-2 * time.Second)
+	a.Observe(slowStart, nil)
+	test.Equal(t, limitOf(a), 5)
+
+	a.Observe(time.Now(), errors.New("some error"))
+	test.Equal(t, limitOf(a), 5)
+
+	a.limit = 10
+	a.Observe(slowStart, nil)
+	test.Equal(t, a.limit, float64(10))
+
+	a.Observe(time.Now(), ErrQueryTimedOut)
+	test.Equal(t, a.limit, float64(5))
+}
+
+func TestLimiterSetLimit(t *testing.T) {
+	l := newQueueingLimiter(2, 0)
+	test.Equal(t, l.Acquire(), true)
+	test.Equal(t, l.Acquire(), true)
+
+	l.SetLimit(1)
+	l.Release()
+	test.Equal(t, l.Acquire(), false)
+
+	served := make(chan struct{})
+	go func() {
+		if _, ok := l.Wait(context.Background(), savedQueryPriority); ok {
+			close(served)
+		}
+	}()
+	waitQueueLength(t, l, 1)
+
+	l.SetLimit(2)
+	<-served
+
+	limit, inUse := l.Limit()
+	test.Equal(t, limit, 2)
+	test.Equal(t, inUse, 2)
+}
+
+func limitOf(a *adaptiveLimit) int {
+	limit, _ := a.limiter.Limit()
+	return limit
+}
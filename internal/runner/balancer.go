@@ -0,0 +1,153 @@
+package runner
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+)
+
+// Strategies used to distribute requests among
+// the endpoints of a mapping.
+const (
+	RoundRobinStrategy = "round-robin"
+	RandomStrategy     = "random"
+	FailoverStrategy   = "failover"
+)
+
+// LoadBalancing configures how the Executor distributes requests
+// among the endpoints of mappings with more than one URL. The
+// Strategy applies to all resources not present in Resources.
+// An endpoint that fails EjectionThreshold consecutive requests
+// is not used during the EjectionDuration, unless all endpoints
+// of the mapping are ejected. A zero threshold disables ejection.
+type LoadBalancing struct {
+	Strategy          string
+	Resources         map[string]string
+	EjectionThreshold int
+	EjectionDuration  time.Duration
+}
+
+// WithLoadBalancing defines how requests are distributed
+// among the endpoints of a mapping.
+func WithLoadBalancing(lb LoadBalancing) ExecutorOption {
+	return func(e *Executor) {
+		e.balancer = newBalancer(lb)
+	}
+}
+
+// balancer keeps the state of the endpoints shared by all requests.
+// Round-robin positions are kept by mapping URL, since the same
+// resource can be mapped to different endpoints by each tenant,
+// while failures are kept by endpoint.
+type balancer struct {
+	mu      sync.Mutex
+	options LoadBalancing
+
+	next         map[string]int
+	failures     map[restql.Endpoint]int
+	ejectedUntil map[restql.Endpoint]time.Time
+}
+
+func newBalancer(lb LoadBalancing) *balancer {
+	return &balancer{
+		options:      lb,
+		next:         make(map[string]int),
+		failures:     make(map[restql.Endpoint]int),
+		ejectedUntil: make(map[restql.Endpoint]time.Time),
+	}
+}
+
+// pick chooses the endpoint of the mapping used by the next request.
+func (b *balancer) pick(mapping restql.Mapping) restql.Endpoint {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	endpoints := b.available(mapping.Endpoints(), time.Now())
+
+	switch b.strategy(mapping.ResourceName()) {
+	case FailoverStrategy:
+		return endpoints[0]
+	case RandomStrategy:
+		return endpoints[rand.Intn(len(endpoints))]
+	default:
+		key := mapping.URL()
+		i := b.next[key] % len(endpoints)
+		b.next[key] = i + 1
+		return endpoints[i]
+	}
+}
+
+// report registers the outcome of a request to the endpoint
+// and returns true if it caused the endpoint ejection.
+func (b *balancer) report(endpoint restql.Endpoint, failed bool) bool {
+	if b.options.EjectionThreshold <= 0 {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !failed {
+		delete(b.failures, endpoint)
+		return false
+	}
+
+	b.failures[endpoint]++
+	if b.failures[endpoint] < b.options.EjectionThreshold {
+		return false
+	}
+
+	delete(b.failures, endpoint)
+	b.ejectedUntil[endpoint] = time.Now().Add(b.options.EjectionDuration)
+
+	return true
+}
+
+// isEndpointFailure tells if the request outcome shows a problem with
+// the endpoint, ignoring the failures caused by restQL itself.
+func isEndpointFailure(a attempt) bool {
+	if a.err == nil {
+		return a.response.StatusCode >= 500
+	}
+
+	switch domain.ErrorKindOf(a.err) {
+	case domain.ErrorKindCancelled, domain.ErrorKindRateLimited:
+		return false
+	default:
+		return true
+	}
+}
+
+func (b *balancer) strategy(resource string) string {
+	if s, found := b.options.Resources[resource]; found {
+		return s
+	}
+
+	return b.options.Strategy
+}
+
+// available returns the endpoints that are not ejected,
+// or all of them if there is none left.
+func (b *balancer) available(endpoints []restql.Endpoint, now time.Time) []restql.Endpoint {
+	available := make([]restql.Endpoint, 0, len(endpoints))
+	for _, e := range endpoints {
+		until, ejected := b.ejectedUntil[e]
+		if ejected && now.Before(until) {
+			continue
+		}
+
+		if ejected {
+			delete(b.ejectedUntil, e)
+		}
+		available = append(available, e)
+	}
+
+	if len(available) == 0 {
+		return endpoints
+	}
+
+	return available
+}
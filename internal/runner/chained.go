@@ -221,8 +221,7 @@ func resolveWithSingleRequest(path []string, done restql.DoneResource) interface
 		return EmptyChained
 	}
 
-	body := done.ResponseBody.Unmarshal()
-	valueFromBody, found := getValueFromBody(path, body)
+	valueFromBody, found := getValueFromBody(path, done.ResponseBody)
 	if found {
 		return valueFromBody
 	}
@@ -243,32 +242,15 @@ func toPath(chain domain.Chain) []string {
 	return r
 }
 
-func getValueFromBody(pathToValue []string, b restql.Body) (interface{}, bool) {
-	if b == nil {
+// getValueFromBody extracts the value on the path from the response body
+// without unmarshalling it entirely, so it can still be sent downstream
+// as the raw upstream bytes.
+func getValueFromBody(pathToValue []string, body *restql.ResponseBody) (interface{}, bool) {
+	if body == nil {
 		return nil, false
 	}
 
-	if len(pathToValue) == 0 {
-		return b, true
-	}
-
-	switch body := b.(type) {
-	case map[string]interface{}:
-		v, found := body[pathToValue[0]]
-		if !found {
-			return nil, false
-		}
-
-		return getValueFromBody(pathToValue[1:], v)
-	case []interface{}:
-		result := make([]interface{}, len(body))
-		for i, v := range body {
-			result[i], _ = getValueFromBody(pathToValue, v)
-		}
-		return result, true
-	default:
-		return nil, false
-	}
+	return body.Extract(pathToValue)
 }
 
 func getValueFromHeader(name string, headers map[string]string) (string, bool) {
@@ -0,0 +1,284 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"mime"
+	"net/url"
+	"strings"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
+)
+
+// decodeResponse converts a non JSON response body into a value that
+// can be used by chains and filters, using the format defined by the
+// mapping or, if it is not defined, by the response Content-Type.
+// Bodies of unknown types that are not valid JSON, like images, are
+// encoded as base64. Bodies that fail to be decoded are kept as they are.
+func decodeResponse(log restql.Logger, mapping restql.Mapping, response restql.HTTPResponse) restql.HTTPResponse {
+	if response.Body == nil || len(response.Body.Bytes()) == 0 {
+		return response
+	}
+
+	policy := mapping.Response()
+	format := policy.Format
+	if format == "" {
+		format = formatFromContentType(responseContentType(response.Headers))
+	}
+
+	var value interface{}
+	var err error
+
+	data := response.Body.Bytes()
+	switch format {
+	case restql.XMLFormat:
+		value, err = decodeXML(data, policy)
+	case restql.TextFormat:
+		value = string(data)
+	case restql.FormFormat:
+		value, err = decodeForm(data)
+	case restql.CSVFormat:
+		value, err = decodeCSV(data, policy)
+	case restql.BinaryFormat:
+		value = encodeBinary(data, responseContentType(response.Headers))
+	default:
+		if !isBinary(response) {
+			return response
+		}
+		value = encodeBinary(data, responseContentType(response.Headers))
+	}
+
+	if err != nil {
+		log.Warn("failed to decode response body", "format", format, "error", err, "url", response.URL)
+		return response
+	}
+
+	response.Body = restql.NewResponseBodyFromValue(log, value)
+	return response
+}
+
+func responseContentType(headers restql.Headers) string {
+	for key, value := range headers {
+		if strings.EqualFold(key, "Content-Type") {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// formatFromContentType returns the format used to decode a body with the
+// given content type, or an empty string if it must not be decoded.
+func formatFromContentType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+
+	switch {
+	case mediaType == "application/xml", mediaType == "text/xml", strings.HasSuffix(mediaType, "+xml"):
+		return restql.XMLFormat
+	case mediaType == "text/csv":
+		return restql.CSVFormat
+	case mediaType == "application/x-www-form-urlencoded":
+		return restql.FormFormat
+	case strings.HasPrefix(mediaType, "text/"):
+		return restql.TextFormat
+	default:
+		return ""
+	}
+}
+
+// isBinary tells if a response body of an unknown content type
+// must be encoded, which is the case if it is not a valid JSON.
+func isBinary(response restql.HTTPResponse) bool {
+	contentType := responseContentType(response.Headers)
+	if contentType == "" {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+		return false
+	}
+
+	return !response.Body.Valid()
+}
+
+// encodeBinary converts a binary body into an object with
+// its content type and its content encoded as base64.
+func encodeBinary(data []byte, contentType string) interface{} {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return map[string]interface{}{
+		"contentType": contentType,
+		"base64":      base64.StdEncoding.EncodeToString(data),
+	}
+}
+
+// decodeXML converts a XML document into an object with the root element
+// as its only key. Elements without attributes or children are decoded as
+// their text, while the others are decoded as objects keyed by the children
+// names, with the attributes prefixed and the text stored on the text key.
+// Repeated children are grouped in a list. All values are strings.
+func decodeXML(data []byte, policy restql.ResponsePolicy) (interface{}, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find xml root element")
+		}
+
+		if start, ok := token.(xml.StartElement); ok {
+			value, err := decodeXMLElement(decoder, start, policy)
+			if err != nil {
+				return nil, err
+			}
+
+			return map[string]interface{}{start.Name.Local: value}, nil
+		}
+	}
+}
+
+func decodeXMLElement(decoder *xml.Decoder, start xml.StartElement, policy restql.ResponsePolicy) (interface{}, error) {
+	element := make(map[string]interface{})
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+		element[policy.AttributePrefix+attr.Name.Local] = attr.Value
+	}
+
+	var text strings.Builder
+	hasChildren := false
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode xml element %s", start.Name.Local)
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			child, err := decodeXMLElement(decoder, token, policy)
+			if err != nil {
+				return nil, err
+			}
+
+			hasChildren = true
+			appendXMLChild(element, token.Name.Local, child)
+		case xml.CharData:
+			text.Write(token)
+		case xml.EndElement:
+			content := strings.TrimSpace(text.String())
+			if len(element) == 0 && !hasChildren {
+				return content, nil
+			}
+
+			if content != "" {
+				element[policy.TextKey] = content
+			}
+
+			return element, nil
+		}
+	}
+}
+
+func appendXMLChild(element map[string]interface{}, name string, child interface{}) {
+	current, found := element[name]
+	if !found {
+		element[name] = child
+		return
+	}
+
+	if list, ok := current.([]interface{}); ok {
+		element[name] = append(list, child)
+		return
+	}
+
+	element[name] = []interface{}{current, child}
+}
+
+// decodeForm converts an URL encoded form into an object, where
+// repeated fields are decoded as a list of values.
+func decodeForm(data []byte) (interface{}, error) {
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode form")
+	}
+
+	result := make(map[string]interface{}, len(values))
+	for key, v := range values {
+		if len(v) == 1 {
+			result[key] = v[0]
+			continue
+		}
+
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			list[i] = item
+		}
+		result[key] = list
+	}
+
+	return result, nil
+}
+
+// decodeCSV converts a CSV document into a list of rows. When the
+// document has a header each row is an object keyed by the header
+// columns, otherwise each row is a list of values.
+func decodeCSV(data []byte, policy restql.ResponsePolicy) (interface{}, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+
+	var header []string
+	if !policy.NoHeader {
+		h, err := reader.Read()
+		if err == io.EOF {
+			return []interface{}{}, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode csv header")
+		}
+		header = h
+	}
+
+	rows := []interface{}{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode csv row")
+		}
+
+		rows = append(rows, makeCSVRow(header, record))
+	}
+}
+
+func makeCSVRow(header []string, record []string) interface{} {
+	if header == nil {
+		row := make([]interface{}, len(record))
+		for i, v := range record {
+			row[i] = v
+		}
+		return row
+	}
+
+	row := make(map[string]interface{}, len(header))
+	for i, column := range header {
+		if i < len(record) {
+			row[column] = record[i]
+		}
+	}
+	return row
+}
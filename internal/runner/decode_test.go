@@ -0,0 +1,135 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestDecodeResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		mapping     string
+		contentType string
+		body        string
+		expected    interface{}
+	}{
+		{
+			"keeps json body",
+			"http://hero.io/hero",
+			"application/json",
+			`{"name": "batman"}`,
+			map[string]interface{}{"name": "batman"},
+		},
+		{
+			"decodes xml by content type",
+			"http://hero.io/hero",
+			"text/xml; charset=utf-8",
+			`<?xml version="1.0"?>
+			<hero id="1" xmlns="http://hero.io">
+				<name>batman</name>
+				<power>money</power>
+				<power>intelligence</power>
+				<city country="us">gotham</city>
+			</hero>`,
+			map[string]interface{}{
+				"hero": map[string]interface{}{
+					"@id":   "1",
+					"name":  "batman",
+					"power": []interface{}{"money", "intelligence"},
+					"city":  map[string]interface{}{"@country": "us", "#text": "gotham"},
+				},
+			},
+		},
+		{
+			"decodes xml with keys defined by mapping",
+			`{"url": "http://hero.io/hero", "response": {"attributePrefix": "_", "textKey": "value"}}`,
+			"application/soap+xml",
+			`<city country="us">gotham</city>`,
+			map[string]interface{}{"city": map[string]interface{}{"_country": "us", "value": "gotham"}},
+		},
+		{
+			"decodes plain text",
+			"http://hero.io/hero",
+			"text/plain",
+			"batman",
+			"batman",
+		},
+		{
+			"decodes form",
+			"http://hero.io/hero",
+			"application/x-www-form-urlencoded",
+			"name=batman&power=money&power=intelligence",
+			map[string]interface{}{"name": "batman", "power": []interface{}{"money", "intelligence"}},
+		},
+		{
+			"decodes csv with header",
+			"http://hero.io/hero",
+			"text/csv",
+			"name,city\nbatman,gotham\nsuperman,metropolis\n",
+			[]interface{}{
+				map[string]interface{}{"name": "batman", "city": "gotham"},
+				map[string]interface{}{"name": "superman", "city": "metropolis"},
+			},
+		},
+		{
+			"decodes csv without header",
+			`{"url": "http://hero.io/hero", "response": {"noHeader": true}}`,
+			"text/csv",
+			"batman,gotham\n",
+			[]interface{}{[]interface{}{"batman", "gotham"}},
+		},
+		{
+			"decodes with format defined by mapping",
+			`{"url": "http://hero.io/hero", "response": {"format": "xml"}}`,
+			"application/octet-stream",
+			"<name>batman</name>",
+			map[string]interface{}{"name": "batman"},
+		},
+		{
+			"encodes binary body as base64",
+			"http://hero.io/hero",
+			"image/png",
+			"\x89PNG\r\n",
+			map[string]interface{}{"contentType": "image/png", "base64": "iVBORw0K"},
+		},
+		{
+			"keeps json body of unknown content type",
+			"http://hero.io/hero",
+			"application/vnd.hero",
+			`{"name": "batman"}`,
+			map[string]interface{}{"name": "batman"},
+		},
+		{
+			"encodes binary with format defined by mapping",
+			`{"url": "http://hero.io/hero", "response": {"format": "binary"}}`,
+			"",
+			"batman",
+			map[string]interface{}{"contentType": "application/octet-stream", "base64": "YmF0bWFu"},
+		},
+		{
+			"keeps body that fails to be decoded",
+			"http://hero.io/hero",
+			"application/xml",
+			"<name>batman",
+			"<name>batman",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping, err := restql.NewMapping("hero", tt.mapping)
+			test.VerifyError(t, err)
+
+			response := restql.HTTPResponse{
+				Headers: restql.Headers{"Content-Type": tt.contentType},
+				Body:    restql.NewResponseBodyFromBytes(test.NoOpLogger, []byte(tt.body)),
+			}
+
+			got := decodeResponse(test.NoOpLogger, mapping, response)
+
+			test.Equal(t, got.Body.Unmarshal(), tt.expected)
+		})
+	}
+}
@@ -47,7 +47,7 @@ func resolveDependsOnIntoStatement(stmt interface{}, doneResources domain.Resour
 func isResolved(doneResource interface{}) bool {
 	switch done := doneResource.(type) {
 	case restql.DoneResource:
-		return done.IgnoreErrors || (done.Status >= 200 && done.Status <= 399)
+		return done.IgnoreErrors || done.Success || (done.Status >= 200 && done.Status <= 399)
 	case restql.DoneResources:
 		resolved := false
 		for _, d := range done {
@@ -2,6 +2,7 @@ package runner
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
@@ -16,11 +17,66 @@ type Executor struct {
 	log             restql.Logger
 	resourceTimeout time.Duration
 	forwardPrefix   string
+	deadlineHeader  string
+	deadlineFormat  string
+	hedgeDelays     map[string]time.Duration
+	statusDefaults  map[string]StatusDefaults
+	balancer        *balancer
+	compression     bool
+}
+
+// ExecutorOption is an Executor parameter configurator
+type ExecutorOption func(e *Executor)
+
+// WithDeadlineHeader makes the Executor forward the time budget
+// of each request to the upstream on the given header, using
+// one of the deadline formats.
+func WithDeadlineHeader(header string, format string) ExecutorOption {
+	return func(e *Executor) {
+		e.deadlineHeader = http.CanonicalHeaderKey(header)
+		e.deadlineFormat = format
+	}
+}
+
+// WithCompression makes the Executor ask the upstreams for compressed
+// responses, unless the mapping of the resource disables it.
+func WithCompression(enabled bool) ExecutorOption {
+	return func(e *Executor) {
+		e.compression = enabled
+	}
+}
+
+// StatusDefaults are the status codes handled by the
+// `ignore-errors` and `success-on` clauses applied to
+// statements of a resource that do not define them.
+// An empty IgnoreErrors list ignores all errors.
+type StatusDefaults struct {
+	IgnoreErrors []int
+	SuccessOn    []int
+}
+
+// WithStatusDefaults defines, by resource name, the status
+// codes handled when statements do not define them.
+func WithStatusDefaults(defaults map[string]StatusDefaults) ExecutorOption {
+	return func(e *Executor) {
+		e.statusDefaults = defaults
+	}
 }
 
 // NewExecutor constructs an instance of Executor.
-func NewExecutor(log restql.Logger, client domain.HTTPClient, resourceTimeout time.Duration, forwardPrefix string) Executor {
-	return Executor{client: client, log: log, resourceTimeout: resourceTimeout, forwardPrefix: forwardPrefix}
+func NewExecutor(log restql.Logger, client domain.HTTPClient, resourceTimeout time.Duration, forwardPrefix string, options ...ExecutorOption) Executor {
+	e := Executor{
+		client:          client,
+		log:             log,
+		resourceTimeout: resourceTimeout,
+		forwardPrefix:   forwardPrefix,
+		balancer:        newBalancer(LoadBalancing{Strategy: RoundRobinStrategy}),
+	}
+	for _, o := range options {
+		o(&e)
+	}
+
+	return e
 }
 
 // DoStatement process a single statement into a result by executing the relevant HTTP calls to the upstream dependency.
@@ -28,9 +84,34 @@ func (e Executor) DoStatement(ctx context.Context, statement domain.Statement, q
 	log := restql.GetLogger(ctx)
 
 	drOptions := DoneResourceOptions{
-		IgnoreErrors: statement.IgnoreErrors,
-		MaxAge:       statement.CacheControl.MaxAge,
-		SMaxAge:      statement.CacheControl.SMaxAge,
+		IgnoreErrors:       statement.IgnoreErrors,
+		IgnoreErrorsStatus: statement.IgnoreErrorsStatus,
+		SuccessOn:          statement.SuccessOn,
+		MaxAge:             statement.CacheControl.MaxAge,
+		SMaxAge:            statement.CacheControl.SMaxAge,
+
+		StaleWhileRevalidate: statement.CacheControl.StaleWhileRevalidate,
+		StaleIfError:         statement.CacheControl.StaleIfError,
+		NoCache:              statement.CacheControl.NoCache,
+		NoStore:              statement.CacheControl.NoStore,
+		Private:              statement.CacheControl.Private,
+		Public:               statement.CacheControl.Public,
+		MustRevalidate:       statement.CacheControl.MustRevalidate,
+		Raw:                  statement.Raw,
+	}
+
+	mapping := queryCtx.Mappings[statement.Resource]
+	applyCachePolicy(&drOptions, mapping.Cache())
+
+	if defaults, found := e.statusDefaults[statement.Resource]; found {
+		if !statement.IgnoreErrors && defaults.IgnoreErrors != nil {
+			drOptions.IgnoreErrors = true
+			drOptions.IgnoreErrorsStatus = defaults.IgnoreErrors
+		}
+
+		if statement.SuccessOn == nil {
+			drOptions.SuccessOn = defaults.SuccessOn
+		}
 	}
 
 	if !statement.DependsOn.Resolved {
@@ -46,20 +127,113 @@ func (e Executor) DoStatement(ctx context.Context, statement domain.Statement, q
 		return emptyChainedResponse
 	}
 
-	request := MakeRequest(e.resourceTimeout, e.forwardPrefix, statement, queryCtx)
+	drOptions.ResourceName = statement.Resource
+	drOptions.PathParams = MakePathParams(statement, mapping)
 
-	log.Debug("executing request for statement", "resource", statement.Resource, "method", statement.Method, "request", request)
+	if err := ValidateFormBody(statement, mapping); err != nil {
+		errorResponse := NewErrorResponse(log, err, restql.HTTPRequest{}, restql.HTTPResponse{StatusCode: 400}, drOptions)
+		log.Debug("request execution skipped due to invalid form body", "error", err, "resource", statement.Resource, "method", statement.Method)
+		return errorResponse
+	}
+
+	target := domain.RequestTarget{Tenant: queryCtx.Options.Tenant, Resource: statement.Resource}
+	ctx = domain.WithRequestTarget(ctx, target)
+
+	result, hedged := e.execute(ctx, statement, mapping, queryCtx)
+
+	attempts, backoff := mapping.Retry()
+	for i := 0; i < attempts && isRetriable(result); i++ {
+		log.Debug("retrying request execution", "resource", statement.Resource, "method", statement.Method, "attempt", i+1)
+		if !waitBackoff(ctx, backoff) {
+			break
+		}
 
-	response, err := e.client.Do(ctx, request)
-	if err != nil {
-		errorResponse := NewErrorResponse(log, err, request, response, drOptions)
-		log.Debug("request execution failed", "error", err, "resource", statement.Resource, "method", statement.Method, "response", errorResponse)
+		result, hedged = e.execute(ctx, statement, mapping, queryCtx)
+	}
+
+	if result.err != nil {
+		errorResponse := NewErrorResponse(log, result.err, result.request, result.response, drOptions)
+		errorResponse.Hedged = hedged
+		errorResponse.HedgeWon = result.hedge
+		log.Debug("request execution failed", "error", result.err, "resource", statement.Resource, "method", statement.Method, "response", errorResponse)
 		return errorResponse
 	}
 
-	dr := NewDoneResource(request, response, drOptions)
+	response := decodeResponse(log, mapping, result.response)
+
+	dr := NewDoneResource(result.request, response, drOptions)
+	dr.Hedged = hedged
+	dr.HedgeWon = result.hedge
+	if statement.Raw {
+		dr.RawResponseBody = result.response.Body
+	}
 
 	log.Debug("request execution done", "resource", statement.Resource, "method", statement.Method, "response", dr)
 
 	return dr
 }
+
+// execute sends the request of the statement to one of
+// the mapping endpoints, reporting if it was hedged.
+func (e Executor) execute(ctx context.Context, statement domain.Statement, mapping restql.Mapping, queryCtx restql.QueryContext) (attempt, bool) {
+	log := restql.GetLogger(ctx)
+
+	request := MakeRequest(ctx, e.resourceTimeout, e.forwardPrefix, statement, queryCtx)
+
+	balanced := len(mapping.Endpoints()) > 1
+	if balanced {
+		endpoint := e.balancer.pick(mapping)
+		request.Schema = endpoint.Schema
+		request.Host = endpoint.Host
+	}
+
+	if _, found := request.Headers[acceptEncodingHeader]; !found && mapping.Compression(e.compression) {
+		request.Headers[acceptEncodingHeader] = acceptedEncodings
+	}
+
+	if e.deadlineHeader != "" {
+		request.Headers[e.deadlineHeader] = MakeDeadlineHeaderValue(e.deadlineFormat, request.Timeout, time.Now())
+	}
+
+	log.Debug("executing request for statement", "resource", statement.Resource, "method", statement.Method, "request", request)
+
+	result, hedged := e.do(ctx, request, e.hedgeDelay(statement, request))
+	if hedged {
+		log.Debug("request execution hedged", "resource", statement.Resource, "method", statement.Method, "hedge-won", result.hedge)
+	}
+
+	if balanced {
+		endpoint := restql.Endpoint{Schema: request.Schema, Host: request.Host}
+		if e.balancer.report(endpoint, isEndpointFailure(result)) {
+			log.Warn("endpoint ejected", "resource", statement.Resource, "schema", endpoint.Schema, "host", endpoint.Host)
+		}
+	}
+
+	return result, hedged
+}
+
+// applyCachePolicy uses the mapping cache times on the ones
+// not defined by the statement, and adds the mapping flags
+// to the ones set by the statement.
+func applyCachePolicy(options *DoneResourceOptions, policy restql.CachePolicy) {
+	if options.MaxAge == nil && policy.MaxAge != nil {
+		options.MaxAge = *policy.MaxAge
+	}
+
+	if options.SMaxAge == nil && policy.SMaxAge != nil {
+		options.SMaxAge = *policy.SMaxAge
+	}
+
+	if options.StaleWhileRevalidate == nil && policy.StaleWhileRevalidate != nil {
+		options.StaleWhileRevalidate = *policy.StaleWhileRevalidate
+	}
+
+	if options.StaleIfError == nil && policy.StaleIfError != nil {
+		options.StaleIfError = *policy.StaleIfError
+	}
+
+	options.NoCache = options.NoCache || policy.NoCache
+	options.NoStore = options.NoStore || policy.NoStore
+	options.Private = options.Private || policy.Private
+	options.MustRevalidate = options.MustRevalidate || policy.MustRevalidate
+}
@@ -0,0 +1,541 @@
+package runner_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/runner"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+// slowPrimaryClient delays the first request it receives,
+// while the following ones return immediately.
+type slowPrimaryClient struct {
+	latency   time.Duration
+	calls     int32
+	cancelled int32
+}
+
+func (c *slowPrimaryClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	if atomic.AddInt32(&c.calls, 1) > 1 {
+		return restql.HTTPResponse{StatusCode: 201}, nil
+	}
+
+	select {
+	case <-time.After(c.latency):
+		return restql.HTTPResponse{StatusCode: 200}, nil
+	case <-ctx.Done():
+		atomic.StoreInt32(&c.cancelled, 1)
+		return restql.HTTPResponse{}, ctx.Err()
+	}
+}
+
+// hostRecorderClient records the host of each request
+// and fails the ones sent to the unhealthy host.
+type hostRecorderClient struct {
+	unhealthy string
+	hosts     []string
+}
+
+func (c *hostRecorderClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	c.hosts = append(c.hosts, request.Host)
+	if request.Host == c.unhealthy {
+		return restql.HTTPResponse{StatusCode: 503}, nil
+	}
+
+	return restql.HTTPResponse{StatusCode: 200}, nil
+}
+
+// headerRecorderClient records the headers of each request.
+type headerRecorderClient struct {
+	headers []restql.Headers
+}
+
+func (c *headerRecorderClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	c.headers = append(c.headers, request.Headers)
+	return restql.HTTPResponse{StatusCode: 200}, nil
+}
+
+func TestExecutorHedging(t *testing.T) {
+	type result struct {
+		Status          int
+		Hedged          bool
+		HedgeWon        bool
+		Calls           int32
+		PrimaryCanceled bool
+	}
+
+	tests := []struct {
+		name      string
+		latency   time.Duration
+		delays    map[string]time.Duration
+		statement domain.Statement
+		expected  result
+	}{
+		{
+			"hedge wins when the first request is slow",
+			time.Second,
+			nil,
+			domain.Statement{Method: domain.FromMethod, Resource: "hero", Hedge: 10},
+			result{Status: 201, Hedged: true, HedgeWon: true, Calls: 2, PrimaryCanceled: true},
+		},
+		{
+			"no hedge when the first request returns within the delay",
+			0,
+			nil,
+			domain.Statement{Method: domain.FromMethod, Resource: "hero", Hedge: 200},
+			result{Status: 200, Calls: 1},
+		},
+		{
+			"hedge with mapping default delay",
+			time.Second,
+			map[string]time.Duration{"hero": 10 * time.Millisecond},
+			domain.Statement{Method: domain.FromMethod, Resource: "hero"},
+			result{Status: 201, Hedged: true, HedgeWon: true, Calls: 2, PrimaryCanceled: true},
+		},
+		{
+			"statement delay overrides mapping default",
+			50 * time.Millisecond,
+			map[string]time.Duration{"hero": 10 * time.Millisecond},
+			domain.Statement{Method: domain.FromMethod, Resource: "hero", Hedge: 0},
+			result{Status: 200, Calls: 1},
+		},
+		{
+			"only from statements are hedged",
+			50 * time.Millisecond,
+			nil,
+			domain.Statement{Method: domain.ToMethod, Resource: "hero", Hedge: 10},
+			result{Status: 200, Calls: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &slowPrimaryClient{latency: tt.latency}
+			executor := runner.NewExecutor(test.NoOpLogger, client, time.Second, "", runner.WithHedgeDelays(tt.delays))
+
+			mapping, _ := restql.NewMapping("hero", "http://hero.io/hero")
+			queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping}}
+			ctx := restql.WithLogger(context.Background(), test.NoOpLogger)
+
+			statement := tt.statement
+			statement.DependsOn.Resolved = true
+
+			dr := executor.DoStatement(ctx, statement, queryCtx)
+
+			got := result{Status: dr.Status, Hedged: dr.Hedged, HedgeWon: dr.HedgeWon, Calls: atomic.LoadInt32(&client.calls)}
+			if got.Hedged {
+				deadline := time.Now().Add(time.Second)
+				for atomic.LoadInt32(&client.cancelled) == 0 && time.Now().Before(deadline) {
+					time.Sleep(time.Millisecond)
+				}
+				got.PrimaryCanceled = atomic.LoadInt32(&client.cancelled) == 1
+			}
+
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
+func TestExecutorHedgingWithMethodOverride(t *testing.T) {
+	client := &slowPrimaryClient{latency: 50 * time.Millisecond}
+	executor := runner.NewExecutor(test.NoOpLogger, client, time.Second, "")
+
+	mapping, err := restql.NewMapping("hero", `{"url": "http://hero.io/hero", "overrides": {"from": {"method": "POST"}}}`)
+	test.VerifyError(t, err)
+
+	queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping}}
+	ctx := restql.WithLogger(context.Background(), test.NoOpLogger)
+
+	statement := domain.Statement{Method: domain.FromMethod, Resource: "hero", Hedge: 10}
+	statement.DependsOn.Resolved = true
+
+	dr := executor.DoStatement(ctx, statement, queryCtx)
+
+	test.Equal(t, dr.Status, 200)
+	test.Equal(t, dr.Hedged, false)
+	test.Equal(t, atomic.LoadInt32(&client.calls), int32(1))
+}
+
+// failingAttemptClient fails the requests whose order, starting
+// at one, is in the failures set, delaying the first request.
+type failingAttemptClient struct {
+	latency  time.Duration
+	failures map[int32]bool
+	calls    int32
+}
+
+func (c *failingAttemptClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	call := atomic.AddInt32(&c.calls, 1)
+	if call == 1 {
+		select {
+		case <-time.After(c.latency):
+		case <-ctx.Done():
+			return restql.HTTPResponse{}, ctx.Err()
+		}
+	}
+
+	if c.failures[call] {
+		return restql.HTTPResponse{}, errors.New("connection reset")
+	}
+
+	return restql.HTTPResponse{StatusCode: 200 + int(call)}, nil
+}
+
+func TestExecutorHedgingFailures(t *testing.T) {
+	type result struct {
+		Status   int
+		Hedged   bool
+		HedgeWon bool
+	}
+
+	tests := []struct {
+		name     string
+		failures map[int32]bool
+		expected result
+	}{
+		{
+			"first request is used when the hedge fails",
+			map[int32]bool{2: true},
+			result{Status: 201, Hedged: true, HedgeWon: false},
+		},
+		{
+			"failure is returned when both requests fail",
+			map[int32]bool{1: true, 2: true},
+			result{Status: 0, Hedged: true, HedgeWon: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &failingAttemptClient{latency: 50 * time.Millisecond, failures: tt.failures}
+			executor := runner.NewExecutor(test.NoOpLogger, client, time.Second, "")
+
+			mapping, _ := restql.NewMapping("hero", "http://hero.io/hero")
+			queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping}}
+			ctx := restql.WithLogger(context.Background(), test.NoOpLogger)
+
+			statement := domain.Statement{Method: domain.FromMethod, Resource: "hero", Hedge: 10}
+			statement.DependsOn.Resolved = true
+
+			dr := executor.DoStatement(ctx, statement, queryCtx)
+
+			test.Equal(t, result{Status: dr.Status, Hedged: dr.Hedged, HedgeWon: dr.HedgeWon}, tt.expected)
+		})
+	}
+}
+
+func TestExecutorInvalidFormBody(t *testing.T) {
+	client := &headerRecorderClient{}
+	executor := runner.NewExecutor(test.NoOpLogger, client, time.Second, "")
+
+	mapping, _ := restql.NewMapping("hero", "http://hero.io/hero")
+	queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping}}
+	ctx := restql.WithLogger(context.Background(), test.NoOpLogger)
+
+	statement := domain.Statement{Method: domain.ToMethod, Resource: "hero", With: domain.Params{Body: domain.AsForm{Value: []interface{}{"batman"}}}}
+	statement.DependsOn.Resolved = true
+
+	dr := executor.DoStatement(ctx, statement, queryCtx)
+
+	test.Equal(t, dr.Status, 400)
+	test.Equal(t, dr.Success, false)
+	test.Equal(t, dr.ErrorMessage, "form body must be an object: got []interface {}")
+	test.Equal(t, len(client.headers), 0)
+}
+
+func TestExecutorStatusDefaults(t *testing.T) {
+	type result struct {
+		Status       int
+		Success      bool
+		IgnoreErrors bool
+	}
+
+	defaults := map[string]runner.StatusDefaults{
+		"reviews": {SuccessOn: []int{404}},
+		"ratings": {IgnoreErrors: []int{}},
+	}
+
+	tests := []struct {
+		name      string
+		statement domain.Statement
+		expected  result
+	}{
+		{
+			"success on defined for mapping",
+			domain.Statement{Method: domain.FromMethod, Resource: "reviews"},
+			result{Status: 404, Success: true},
+		},
+		{
+			"success on defined by statement overrides mapping",
+			domain.Statement{Method: domain.FromMethod, Resource: "reviews", SuccessOn: []int{410}},
+			result{Status: 404, Success: false},
+		},
+		{
+			"ignore all errors defined for mapping",
+			domain.Statement{Method: domain.FromMethod, Resource: "ratings"},
+			result{Status: 404, IgnoreErrors: true},
+		},
+		{
+			"ignore errors defined by statement overrides mapping",
+			domain.Statement{Method: domain.FromMethod, Resource: "ratings", IgnoreErrors: true, IgnoreErrorsStatus: []int{409}},
+			result{Status: 404, IgnoreErrors: false},
+		},
+		{
+			"no defaults for mapping",
+			domain.Statement{Method: domain.FromMethod, Resource: "hero"},
+			result{Status: 404},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := runner.NewExecutor(test.NoOpLogger, statusEchoClient{}, time.Second, "", runner.WithStatusDefaults(defaults))
+
+			mapping, _ := restql.NewMapping(tt.statement.Resource, "http://api.io/"+tt.statement.Resource)
+			queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{tt.statement.Resource: mapping}}
+			ctx := restql.WithLogger(context.Background(), test.NoOpLogger)
+
+			statement := tt.statement
+			statement.DependsOn.Resolved = true
+			statement.With = domain.Params{Values: map[string]interface{}{"status": 404}}
+
+			dr := executor.DoStatement(ctx, statement, queryCtx)
+
+			test.Equal(t, result{Status: dr.Status, Success: dr.Success, IgnoreErrors: dr.IgnoreErrors}, tt.expected)
+		})
+	}
+}
+
+func TestExecutorCachePolicy(t *testing.T) {
+	tests := []struct {
+		name         string
+		cacheControl domain.CacheControl
+		expected     restql.ResourceCacheControl
+	}{
+		{
+			"mapping directives used when statement does not define them",
+			domain.CacheControl{},
+			restql.ResourceCacheControl{
+				Private:        true,
+				MustRevalidate: true,
+				MaxAge:         restql.ResourceCacheControlValue{Exist: true, Time: 60},
+			},
+		},
+		{
+			"mapping flags added to statement directives",
+			domain.CacheControl{MaxAge: 10, StaleIfError: 30},
+			restql.ResourceCacheControl{
+				Private:        true,
+				MustRevalidate: true,
+				MaxAge:         restql.ResourceCacheControlValue{Exist: true, Time: 10},
+				StaleIfError:   restql.ResourceCacheControlValue{Exist: true, Time: 30},
+			},
+		},
+		{
+			"statement no-store overrides mapping times",
+			domain.CacheControl{NoStore: true},
+			restql.ResourceCacheControl{NoStore: true, Private: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := runner.NewExecutor(test.NoOpLogger, &headerRecorderClient{}, time.Second, "")
+
+			mapping, err := restql.NewMapping("hero", `{"url": "http://hero.io/hero", "cache": {"maxAge": 60, "private": true, "mustRevalidate": true}}`)
+			test.VerifyError(t, err)
+
+			queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping}}
+			ctx := restql.WithLogger(context.Background(), test.NoOpLogger)
+
+			statement := domain.Statement{Method: domain.FromMethod, Resource: "hero", CacheControl: tt.cacheControl}
+			statement.DependsOn.Resolved = true
+
+			dr := executor.DoStatement(ctx, statement, queryCtx)
+
+			test.Equal(t, dr.CacheControl, tt.expected)
+		})
+	}
+}
+
+func TestExecutorLoadBalancing(t *testing.T) {
+	tests := []struct {
+		name          string
+		loadBalancing runner.LoadBalancing
+		unhealthy     string
+		expected      []string
+	}{
+		{
+			"round robin among endpoints",
+			runner.LoadBalancing{Strategy: runner.RoundRobinStrategy},
+			"",
+			[]string{"hero-1.io", "hero-2.io", "hero-3.io", "hero-1.io"},
+		},
+		{
+			"failover keeps using primary endpoint",
+			runner.LoadBalancing{Strategy: runner.FailoverStrategy},
+			"",
+			[]string{"hero-1.io", "hero-1.io", "hero-1.io", "hero-1.io"},
+		},
+		{
+			"failover to secondary endpoint when primary is ejected",
+			runner.LoadBalancing{Strategy: runner.FailoverStrategy, EjectionThreshold: 2, EjectionDuration: time.Minute},
+			"hero-1.io",
+			[]string{"hero-1.io", "hero-1.io", "hero-2.io", "hero-2.io"},
+		},
+		{
+			"strategy defined for resource",
+			runner.LoadBalancing{Strategy: runner.RoundRobinStrategy, Resources: map[string]string{"hero": runner.FailoverStrategy}},
+			"",
+			[]string{"hero-1.io", "hero-1.io", "hero-1.io", "hero-1.io"},
+		},
+		{
+			"round robin skips ejected endpoint",
+			runner.LoadBalancing{Strategy: runner.RoundRobinStrategy, EjectionThreshold: 1, EjectionDuration: time.Minute},
+			"hero-2.io",
+			[]string{"hero-1.io", "hero-2.io", "hero-1.io", "hero-3.io"},
+		},
+		{
+			"ejected endpoint returns after ejection duration",
+			runner.LoadBalancing{Strategy: runner.FailoverStrategy, EjectionThreshold: 1},
+			"hero-1.io",
+			[]string{"hero-1.io", "hero-1.io", "hero-1.io", "hero-1.io"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &hostRecorderClient{unhealthy: tt.unhealthy}
+			executor := runner.NewExecutor(test.NoOpLogger, client, time.Second, "", runner.WithLoadBalancing(tt.loadBalancing))
+
+			mapping, err := restql.NewMapping("hero", "http://hero-1.io/hero/:id, http://hero-2.io/hero/:id, http://hero-3.io/hero/:id")
+			test.VerifyError(t, err)
+
+			queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping}}
+			ctx := restql.WithLogger(context.Background(), test.NoOpLogger)
+
+			statement := domain.Statement{Method: domain.FromMethod, Resource: "hero"}
+			statement.DependsOn.Resolved = true
+			statement.With = domain.Params{Values: map[string]interface{}{"id": "1"}}
+
+			for range tt.expected {
+				executor.DoStatement(ctx, statement, queryCtx)
+			}
+
+			test.Equal(t, client.hosts, tt.expected)
+		})
+	}
+}
+
+func TestExecutorRetry(t *testing.T) {
+	type result struct {
+		Status int
+		Hosts  []string
+	}
+
+	tests := []struct {
+		name      string
+		mapping   string
+		method    string
+		unhealthy string
+		expected  result
+	}{
+		{
+			"retry failed request",
+			`{"url": "http://hero-1.io/hero", "retry": {"attempts": 2}}`,
+			domain.FromMethod,
+			"hero-1.io",
+			result{Status: 503, Hosts: []string{"hero-1.io", "hero-1.io", "hero-1.io"}},
+		},
+		{
+			"retry on next endpoint",
+			`{"url": "http://hero-1.io/hero, http://hero-2.io/hero", "retry": {"attempts": 2, "backoff": "1ms"}}`,
+			domain.FromMethod,
+			"hero-1.io",
+			result{Status: 200, Hosts: []string{"hero-1.io", "hero-2.io"}},
+		},
+		{
+			"no retry on successful request",
+			`{"url": "http://hero-1.io/hero", "retry": {"attempts": 2}}`,
+			domain.FromMethod,
+			"",
+			result{Status: 200, Hosts: []string{"hero-1.io"}},
+		},
+		{
+			"no retry on non idempotent method",
+			`{"url": "http://hero-1.io/hero", "retry": {"attempts": 2}}`,
+			domain.ToMethod,
+			"hero-1.io",
+			result{Status: 503, Hosts: []string{"hero-1.io"}},
+		},
+		{
+			"no retry on method overridden to non idempotent",
+			`{"url": "http://hero-1.io/hero", "retry": {"attempts": 2}, "overrides": {"from": {"method": "POST"}}}`,
+			domain.FromMethod,
+			"hero-1.io",
+			result{Status: 503, Hosts: []string{"hero-1.io"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &hostRecorderClient{unhealthy: tt.unhealthy}
+			executor := runner.NewExecutor(test.NoOpLogger, client, time.Second, "")
+
+			mapping, err := restql.NewMapping("hero", tt.mapping)
+			test.VerifyError(t, err)
+
+			queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping}}
+			ctx := restql.WithLogger(context.Background(), test.NoOpLogger)
+
+			statement := domain.Statement{Method: tt.method, Resource: "hero"}
+			statement.DependsOn.Resolved = true
+
+			dr := executor.DoStatement(ctx, statement, queryCtx)
+
+			test.Equal(t, result{Status: dr.Status, Hosts: client.hosts}, tt.expected)
+		})
+	}
+}
+
+func TestExecutorCompression(t *testing.T) {
+	tests := []struct {
+		name        string
+		compression bool
+		mapping     string
+		headers     map[string]interface{}
+		expected    string
+	}{
+		{"no compression by default", false, "http://hero.io/hero", nil, ""},
+		{"compression enabled for all mappings", true, "http://hero.io/hero", nil, "gzip, br, deflate"},
+		{"compression disabled by mapping", true, `{"url": "http://hero.io/hero", "compression": false}`, nil, ""},
+		{"compression enabled by mapping", false, `{"url": "http://hero.io/hero", "compression": true}`, nil, "gzip, br, deflate"},
+		{"encoding defined by statement", true, "http://hero.io/hero", map[string]interface{}{"Accept-Encoding": "gzip"}, "gzip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &headerRecorderClient{}
+			executor := runner.NewExecutor(test.NoOpLogger, client, time.Second, "", runner.WithCompression(tt.compression))
+
+			mapping, err := restql.NewMapping("hero", tt.mapping)
+			test.VerifyError(t, err)
+
+			queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping}}
+			ctx := restql.WithLogger(context.Background(), test.NoOpLogger)
+
+			statement := domain.Statement{Method: domain.FromMethod, Resource: "hero", Headers: tt.headers}
+			statement.DependsOn.Resolved = true
+
+			executor.DoStatement(ctx, statement, queryCtx)
+
+			test.Equal(t, client.headers[0]["Accept-Encoding"], tt.expected)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package runner
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+)
+
+// WithHedgeDelays defines, by resource name, how long the Executor
+// waits for the response of a `from` statement before sending a
+// duplicate request. It is overridden by the `hedge after` clause.
+func WithHedgeDelays(delays map[string]time.Duration) ExecutorOption {
+	return func(e *Executor) {
+		e.hedgeDelays = delays
+	}
+}
+
+// attempt is the outcome of one of the requests
+// sent to the upstream for a statement.
+type attempt struct {
+	request  restql.HTTPRequest
+	response restql.HTTPResponse
+	err      error
+	hedge    bool
+}
+
+// hedgeDelay returns the time to wait before hedging the request
+// of the statement, or zero if it is not eligible. Only requests
+// with safe methods are hedged, taken from the request since the
+// mapping can override the one implied by the statement.
+func (e Executor) hedgeDelay(statement domain.Statement, request restql.HTTPRequest) time.Duration {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+	default:
+		return 0
+	}
+
+	if delay, ok := statement.Hedge.(int); ok {
+		return time.Millisecond * time.Duration(delay)
+	}
+
+	return e.hedgeDelays[statement.Resource]
+}
+
+// do executes the request, hedging it when the delay is
+// shorter than its timeout.
+func (e Executor) do(ctx context.Context, request restql.HTTPRequest, hedgeDelay time.Duration) (attempt, bool) {
+	if hedgeDelay <= 0 || hedgeDelay >= request.Timeout {
+		response, err := e.client.Do(ctx, request)
+		return attempt{request: request, response: response, err: err}, false
+	}
+
+	return e.doHedged(ctx, request, hedgeDelay)
+}
+
+// doHedged executes the request and, if it has not returned within
+// the delay, sends a duplicate one. The first successful response
+// is used and the other request is cancelled, while a failure is
+// only returned if both requests fail. It also reports if the
+// duplicate request was sent.
+func (e Executor) doHedged(ctx context.Context, request restql.HTTPRequest, delay time.Duration) (attempt, bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan attempt, 2)
+	do := func(r restql.HTTPRequest, hedge bool) {
+		response, err := e.client.Do(ctx, r)
+		results <- attempt{request: r, response: response, err: err, hedge: hedge}
+	}
+
+	go do(request, false)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case a := <-results:
+		return a, false
+	case <-timer.C:
+	}
+
+	if ctx.Err() != nil {
+		return <-results, false
+	}
+
+	go do(e.makeHedgeRequest(request, delay), true)
+
+	a := <-results
+	if a.err == nil {
+		return a, true
+	}
+
+	if other := <-results; other.err == nil {
+		return other, true
+	}
+
+	return a, true
+}
+
+// makeHedgeRequest copies the request keeping the time budget of
+// the original one, since the duplicate is sent after the delay.
+func (e Executor) makeHedgeRequest(request restql.HTTPRequest, delay time.Duration) restql.HTTPRequest {
+	hedge := request
+	hedge.Timeout = request.Timeout - delay
+
+	if e.deadlineHeader != "" {
+		headers := make(restql.Headers, len(request.Headers))
+		for k, v := range request.Headers {
+			headers[k] = v
+		}
+		headers[e.deadlineHeader] = MakeDeadlineHeaderValue(e.deadlineFormat, hedge.Timeout, time.Now())
+		hedge.Headers = headers
+	}
+
+	return hedge
+}
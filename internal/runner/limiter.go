@@ -0,0 +1,248 @@
+package runner
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// Priority classes used to order callers waiting on a limiter.
+// Lower values are served first.
+const (
+	savedQueryPriority = iota
+	adHocQueryPriority
+	priorityClasses
+)
+
+// quota composes limiters that must all grant a token
+// for an execution to proceed, each one with its own
+// error to report when denying it.
+type quota struct {
+	limiters []*limiter
+	errs     []error
+}
+
+func (q *quota) add(l *limiter, err error) {
+	q.limiters = append(q.limiters, l)
+	q.errs = append(q.errs, err)
+}
+
+// Acquire will try to reserve a token on every limiter.
+// If any of them is full, the tokens already reserved are
+// released and its error is returned.
+func (q quota) Acquire() error {
+	for i, l := range q.limiters {
+		if !l.Acquire() {
+			q.releaseUntil(i)
+			return q.errs[i]
+		}
+	}
+
+	return nil
+}
+
+// Wait will try to reserve a token on every limiter, queueing
+// with the given priority while they are full until the context
+// is done. It returns the total time spent waiting.
+func (q quota) Wait(ctx context.Context, priority int) (time.Duration, error) {
+	var waited time.Duration
+	for i, l := range q.limiters {
+		w, ok := l.Wait(ctx, priority)
+		waited += w
+		if !ok {
+			q.releaseUntil(i)
+			return waited, q.errs[i]
+		}
+	}
+
+	return waited, nil
+}
+
+func (q quota) releaseUntil(i int) {
+	for _, acquired := range q.limiters[:i] {
+		acquired.Release()
+	}
+}
+
+// Release will return a token to every limiter.
+func (q quota) Release() {
+	for _, l := range q.limiters {
+		l.Release()
+	}
+}
+
+type waiter struct {
+	ready chan struct{}
+}
+
+type limiter struct {
+	mu sync.Mutex
+
+	unlimited bool
+	limit     int32
+	bucket    int32
+
+	maxQueueLength int
+	queueLength    int
+	queues         [priorityClasses]*list.List
+}
+
+func newLimiter(limit int32) *limiter {
+	return newQueueingLimiter(limit, 0)
+}
+
+func newQueueingLimiter(limit int32, maxQueueLength int) *limiter {
+	l := &limiter{unlimited: limit <= 0, limit: limit, bucket: limit, maxQueueLength: maxQueueLength}
+	for i := range l.queues {
+		l.queues[i] = list.New()
+	}
+
+	return l
+}
+
+// Acquire will try to reserve a token on limiter.
+// If the limiter bucket is full (default case on select), it will fail.
+func (l *limiter) Acquire() bool {
+	if l.unlimited {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.bucket <= 0 {
+		return false
+	}
+
+	l.bucket = l.bucket - 1
+	return true
+}
+
+// Wait will try to reserve a token on limiter, queueing the caller
+// if the bucket is full. Callers are served by priority class and,
+// within the same class, by arrival order. It gives up when the
+// context is done or when the queue is already at its maximum length.
+func (l *limiter) Wait(ctx context.Context, priority int) (time.Duration, bool) {
+	if l.unlimited {
+		return 0, true
+	}
+
+	l.mu.Lock()
+
+	if l.bucket > 0 {
+		l.bucket = l.bucket - 1
+		l.mu.Unlock()
+		return 0, true
+	}
+
+	if ctx.Err() != nil || (l.maxQueueLength > 0 && l.queueLength >= l.maxQueueLength) {
+		l.mu.Unlock()
+		return 0, false
+	}
+
+	if priority < 0 || priority >= priorityClasses {
+		priority = priorityClasses - 1
+	}
+
+	w := &waiter{ready: make(chan struct{})}
+	elem := l.queues[priority].PushBack(w)
+	l.queueLength++
+	l.mu.Unlock()
+
+	start := time.Now()
+
+	select {
+	case <-w.ready:
+		return time.Since(start), true
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		select {
+		case <-w.ready:
+			// the token was handed over concurrently
+			return time.Since(start), true
+		default:
+		}
+
+		l.queues[priority].Remove(elem)
+		l.queueLength--
+		return time.Since(start), false
+	}
+}
+
+// Release will return a token to the bucket, handing it
+// directly to the next queued caller if there is one.
+func (l *limiter) Release() {
+	if l.unlimited {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.bucket >= 0 && l.handOver() {
+		return
+	}
+
+	if l.bucket >= l.limit {
+		l.bucket = l.limit
+		return
+	}
+
+	l.bucket = l.bucket + 1
+}
+
+// SetLimit changes the number of tokens of the limiter.
+// When the limit is lowered below the tokens in use the
+// bucket stays negative until enough of them are released,
+// and when it is raised the new tokens are handed to the
+// queued callers first.
+func (l *limiter) SetLimit(limit int32) {
+	if l.unlimited {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.bucket = l.bucket + limit - l.limit
+	l.limit = limit
+
+	for l.bucket > 0 && l.handOver() {
+		l.bucket = l.bucket - 1
+	}
+}
+
+// handOver gives a token to the first caller of the highest
+// priority queue, returning false if no caller is waiting.
+func (l *limiter) handOver() bool {
+	for _, q := range l.queues {
+		if front := q.Front(); front != nil {
+			q.Remove(front)
+			l.queueLength--
+			close(front.Value.(*waiter).ready)
+			return true
+		}
+	}
+
+	return false
+}
+
+// Limit returns the current number of tokens of the limiter
+// and how many of them are in use.
+func (l *limiter) Limit() (limit int, inUse int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return int(l.limit), int(l.limit - l.bucket)
+}
+
+// QueueLength returns the number of callers waiting for a token.
+func (l *limiter) QueueLength() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.queueLength
+}
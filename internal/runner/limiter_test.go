@@ -0,0 +1,65 @@
+package runner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestLimiterWaitPriority(t *testing.T) {
+	l := newQueueingLimiter(1, 0)
+	test.Equal(t, l.Acquire(), true)
+
+	served := make(chan int, 3)
+	wait := func(priority int) {
+		_, ok := l.Wait(context.Background(), priority)
+		if ok {
+			served <- priority
+		}
+	}
+
+	go wait(adHocQueryPriority)
+	waitQueueLength(t, l, 1)
+	go wait(savedQueryPriority)
+	waitQueueLength(t, l, 2)
+
+	l.Release()
+	test.Equal(t, <-served, savedQueryPriority)
+
+	l.Release()
+	test.Equal(t, <-served, adHocQueryPriority)
+
+	l.Release()
+	test.Equal(t, l.Acquire(), true)
+}
+
+func TestLimiterWaitBounds(t *testing.T) {
+	l := newQueueingLimiter(1, 1)
+	test.Equal(t, l.Acquire(), true)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	waited, ok := l.Wait(ctx, savedQueryPriority)
+	test.Equal(t, ok, false)
+	test.Equal(t, waited >= 10*time.Millisecond, true)
+	test.Equal(t, l.QueueLength(), 0)
+
+	go l.Wait(context.Background(), savedQueryPriority)
+	waitQueueLength(t, l, 1)
+
+	_, ok = l.Wait(context.Background(), savedQueryPriority)
+	test.Equal(t, ok, false)
+}
+
+func waitQueueLength(t *testing.T, l *limiter, length int) {
+	deadline := time.Now().Add(time.Second)
+	for l.QueueLength() != length {
+		if time.Now().After(deadline) {
+			t.Fatalf("limiter queue length never reached %d", length)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
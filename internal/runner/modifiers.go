@@ -36,5 +36,26 @@ func applyCacheModifiers(modifiers domain.Modifiers, statement domain.Statement)
 		cc.SMaxAge = smaxAge
 	}
 
+	staleWhileRevalidate, found := modifiers["stale-while-revalidate"]
+	if cc.StaleWhileRevalidate == nil && found {
+		cc.StaleWhileRevalidate = staleWhileRevalidate
+	}
+
+	staleIfError, found := modifiers["stale-if-error"]
+	if cc.StaleIfError == nil && found {
+		cc.StaleIfError = staleIfError
+	}
+
+	cc.NoCache = cc.NoCache || isModifierEnabled(modifiers, "no-cache")
+	cc.NoStore = cc.NoStore || isModifierEnabled(modifiers, "no-store")
+	cc.Private = cc.Private || isModifierEnabled(modifiers, "private")
+	cc.Public = cc.Public || isModifierEnabled(modifiers, "public")
+	cc.MustRevalidate = cc.MustRevalidate || isModifierEnabled(modifiers, "must-revalidate")
+
 	return cc
 }
+
+func isModifierEnabled(modifiers domain.Modifiers, key string) bool {
+	enabled, ok := modifiers[key].(bool)
+	return ok && enabled
+}
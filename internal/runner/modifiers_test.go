@@ -90,6 +90,30 @@ func TestApplyModifiers(t *testing.T) {
 				CacheControl: domain.CacheControl{MaxAge: 400},
 			}},
 		},
+		{
+			"should apply stale cache directives modifiers to statement",
+			domain.Modifiers{"stale-while-revalidate": 30, "stale-if-error": 600},
+			domain.Resources{"hero": domain.Statement{
+				Resource:     "hero",
+				CacheControl: domain.CacheControl{StaleIfError: 300},
+			}},
+			domain.Resources{"hero": domain.Statement{
+				Resource:     "hero",
+				CacheControl: domain.CacheControl{StaleWhileRevalidate: 30, StaleIfError: 300},
+			}},
+		},
+		{
+			"should apply cache directives flags modifiers to statement",
+			domain.Modifiers{"private": true, "must-revalidate": true},
+			domain.Resources{"hero": domain.Statement{
+				Resource:     "hero",
+				CacheControl: domain.CacheControl{NoStore: true},
+			}},
+			domain.Resources{"hero": domain.Statement{
+				Resource:     "hero",
+				CacheControl: domain.CacheControl{NoStore: true, Private: true, MustRevalidate: true},
+			}},
+		},
 		{
 			"should apply modifiers to all statements",
 			domain.Modifiers{"max-age": 600, "s-max-age": 800},
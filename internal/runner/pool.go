@@ -0,0 +1,112 @@
+package runner
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+)
+
+const (
+	defaultWorkerPoolSize = 10000
+	workerIdleTimeout     = 10 * time.Second
+	saturatedPoolRetry    = 10 * time.Millisecond
+)
+
+// task is a statement execution run by the worker pool. It is
+// passed by value to avoid allocating a closure for each call.
+type task struct {
+	execution   *execution
+	resourceID  domain.ResourceID
+	statement   domain.Statement
+	multiplexed *multiplexedResult
+	responses   restql.DoneResources
+	index       int
+}
+
+// workerPool runs tasks on a bounded set of long-lived goroutines
+// shared by all queries. Workers are spawned on demand up to the
+// pool size and exit after staying idle, hence the pool only holds
+// the goroutines required by the current workload.
+type workerPool struct {
+	size    int32
+	workers int32
+	tasks   chan task
+}
+
+func newWorkerPool(size int) *workerPool {
+	if size <= 0 {
+		size = defaultWorkerPoolSize
+	}
+
+	return &workerPool{size: int32(size), tasks: make(chan task)}
+}
+
+// Submit hands the task to an idle worker, spawning a new one if
+// none is available and the pool is not full. Otherwise, it waits
+// for a worker to become idle, giving up when the context is done.
+func (p *workerPool) Submit(ctx context.Context, t task) bool {
+	for {
+		select {
+		case p.tasks <- t:
+			return true
+		default:
+		}
+
+		if p.spawn(t) {
+			return true
+		}
+
+		timer := time.NewTimer(saturatedPoolRetry)
+		select {
+		case p.tasks <- t:
+			timer.Stop()
+			return true
+		case <-ctx.Done():
+			timer.Stop()
+			return false
+		case <-timer.C:
+			// workers may have exited while the pool looked full
+		}
+	}
+}
+
+func (p *workerPool) spawn(t task) bool {
+	for {
+		n := atomic.LoadInt32(&p.workers)
+		if n >= p.size {
+			return false
+		}
+
+		if atomic.CompareAndSwapInt32(&p.workers, n, n+1) {
+			go p.work(t)
+			return true
+		}
+	}
+}
+
+func (p *workerPool) work(t task) {
+	idle := time.NewTimer(workerIdleTimeout)
+	defer idle.Stop()
+
+	for {
+		t.execution.run(t)
+
+		if !idle.Stop() {
+			select {
+			case <-idle.C:
+			default:
+			}
+		}
+		idle.Reset(workerIdleTimeout)
+
+		select {
+		case t = <-p.tasks:
+		case <-idle.C:
+			atomic.AddInt32(&p.workers, -1)
+			return
+		}
+	}
+}
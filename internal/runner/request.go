@@ -1,8 +1,12 @@
 package runner
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -10,6 +14,15 @@ import (
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 )
 
+// acceptedEncodings are the compressions requested to the
+// upstreams, which the HTTP client decodes before the body
+// is used. The client Accept-Encoding header is not forwarded
+// since it refers to the restQL response.
+const (
+	acceptEncodingHeader = "Accept-Encoding"
+	acceptedEncodings    = "gzip, br, deflate"
+)
+
 var disallowedHeaders = []string{
 	"host",
 	"content-type",
@@ -17,23 +30,26 @@ var disallowedHeaders = []string{
 	"connection",
 	"origin",
 	"accept-encoding",
+	"if-none-match",
 }
 
 var queryMethodToHTTPMethod = map[string]string{
-	domain.FromMethod:   http.MethodGet,
-	domain.ToMethod:     http.MethodPost,
-	domain.IntoMethod:   http.MethodPut,
-	domain.UpdateMethod: http.MethodPatch,
-	domain.DeleteMethod: http.MethodDelete,
+	domain.FromMethod:    http.MethodGet,
+	domain.ToMethod:      http.MethodPost,
+	domain.IntoMethod:    http.MethodPut,
+	domain.UpdateMethod:  http.MethodPatch,
+	domain.DeleteMethod:  http.MethodDelete,
+	domain.CheckMethod:   http.MethodHead,
+	domain.OptionsMethod: http.MethodOptions,
 }
 
 // MakeRequest builds a HTTPRequest from a statement.
-func MakeRequest(defaultResourceTimeout time.Duration, forwardPrefix string, statement domain.Statement, queryCtx restql.QueryContext) restql.HTTPRequest {
+func MakeRequest(ctx context.Context, defaultResourceTimeout time.Duration, forwardPrefix string, statement domain.Statement, queryCtx restql.QueryContext) restql.HTTPRequest {
 	mapping := queryCtx.Mappings[statement.Resource]
-	method := queryMethodToHTTPMethod[statement.Method]
-	headers := makeHeaders(statement, queryCtx)
+	method := makeMethod(statement, mapping)
+	headers := makeHeaders(statement, mapping, queryCtx)
 	path := mapping.PathWithParams(statement.With.Values)
-	timeout := parseTimeout(defaultResourceTimeout, statement)
+	timeout := clampTimeout(ctx, parseTimeout(defaultResourceTimeout, statement, mapping))
 
 	queryParams := makeQueryParams(forwardPrefix, statement, mapping, queryCtx)
 
@@ -47,21 +63,102 @@ func MakeRequest(defaultResourceTimeout time.Duration, forwardPrefix string, sta
 		Timeout: timeout,
 	}
 
-	if statement.Method == domain.ToMethod || statement.Method == domain.UpdateMethod || statement.Method == domain.IntoMethod {
+	if sendsBody(statement, mapping) {
 		req.Body = makeBody(statement, mapping)
+
+		if contentType, found := bodyContentType(statement); found {
+			req.Headers["Content-Type"] = contentType
+		}
 	}
 
 	return req
 }
 
+// ErrInvalidFormBody is returned when the body of a statement
+// sent as a form cannot be encoded as form fields.
+var ErrInvalidFormBody = errors.New("form body must be an object")
+
+// ValidateFormBody returns an error if the statement body is sent
+// as a form but is neither an object nor an already encoded string.
+func ValidateFormBody(statement domain.Statement, mapping restql.Mapping) error {
+	if _, found := bodyContentType(statement); !found || !sendsBody(statement, mapping) {
+		return nil
+	}
+
+	switch body := makeBody(statement, mapping).(type) {
+	case nil, string, map[string]interface{}:
+		return nil
+	default:
+		return fmt.Errorf("%w: got %T", ErrInvalidFormBody, body)
+	}
+}
+
+func sendsBody(statement domain.Statement, mapping restql.Mapping) bool {
+	switch statement.Method {
+	case domain.ToMethod, domain.UpdateMethod, domain.IntoMethod:
+		return true
+	default:
+		return paramsAsBody(statement, mapping)
+	}
+}
+
+// makeMethod returns the HTTP method of the statement,
+// which can be overridden by the mapping.
+func makeMethod(statement domain.Statement, mapping restql.Mapping) string {
+	override, found := mapping.MethodOverride(statement.Method)
+	if found && override.Method != "" {
+		return strings.ToUpper(override.Method)
+	}
+
+	return queryMethodToHTTPMethod[statement.Method]
+}
+
+// paramsAsBody tells if the mapping makes the statement
+// send its parameters as the body.
+func paramsAsBody(statement domain.Statement, mapping restql.Mapping) bool {
+	override, found := mapping.MethodOverride(statement.Method)
+	return found && override.Body
+}
+
+// MakePathParams returns the statement parameters
+// used as path parameters in the resource mapping.
+func MakePathParams(statement domain.Statement, mapping restql.Mapping) map[string]interface{} {
+	var params map[string]interface{}
+
+	for key, value := range statement.With.Values {
+		if !mapping.IsPathParam(key) {
+			continue
+		}
+
+		if params == nil {
+			params = make(map[string]interface{})
+		}
+		params[key] = value
+	}
+
+	return params
+}
+
 func makeBody(statement domain.Statement, mapping restql.Mapping) restql.Body {
 	if statement.With.Body != nil {
-		return statement.With.Body
+		switch body := statement.With.Body.(type) {
+		case domain.AsForm:
+			return body.Target()
+		case domain.AsMultipart:
+			return body.Target()
+		default:
+			return body
+		}
 	}
 
 	result := make(map[string]interface{})
 	for key, value := range getValueForBody(statement, mapping) {
-		if value, ok := value.(domain.AsBody); ok {
+		switch value := value.(type) {
+		case domain.AsBody:
+			return parseBodyValue(value.Target())
+		case domain.AsForm:
+			return parseBodyValue(value.Target())
+		case domain.AsMultipart:
 			return parseBodyValue(value.Target())
 		}
 
@@ -74,6 +171,26 @@ func makeBody(statement domain.Statement, mapping restql.Mapping) restql.Body {
 	return result
 }
 
+// bodyContentType returns the content type defined
+// by the `as-form` and `as-multipart` functions.
+func bodyContentType(statement domain.Statement) (string, bool) {
+	values := []interface{}{statement.With.Body}
+	for _, value := range statement.With.Values {
+		values = append(values, value)
+	}
+
+	for _, value := range values {
+		switch value.(type) {
+		case domain.AsForm:
+			return "application/x-www-form-urlencoded", true
+		case domain.AsMultipart:
+			return "multipart/form-data", true
+		}
+	}
+
+	return "", false
+}
+
 func getValueForBody(statement domain.Statement, mapping restql.Mapping) map[string]interface{} {
 	values := make(map[string]interface{})
 
@@ -139,7 +256,7 @@ func getValueForQueryParams(statement domain.Statement, mapping restql.Mapping)
 			continue
 		}
 
-		if statement.Method == domain.FromMethod || statement.Method == domain.DeleteMethod {
+		if sendsParamsAsQuery(statement.Method) && !paramsAsBody(statement, mapping) {
 			values[key] = value
 			continue
 		}
@@ -148,6 +265,15 @@ func getValueForQueryParams(statement domain.Statement, mapping restql.Mapping)
 	return values
 }
 
+func sendsParamsAsQuery(method string) bool {
+	switch method {
+	case domain.FromMethod, domain.DeleteMethod, domain.CheckMethod, domain.OptionsMethod:
+		return true
+	default:
+		return false
+	}
+}
+
 func isPrimitiveValue(value interface{}) bool {
 	if value == nil {
 		return false
@@ -186,8 +312,19 @@ func getForwardParams(forwardPrefix string, queryCtx restql.QueryContext) map[st
 	return r
 }
 
-func makeHeaders(statement domain.Statement, queryCtx restql.QueryContext) map[string]string {
-	headers := getForwardHeaders(queryCtx)
+// makeHeaders builds the request headers, starting from the mapping
+// defaults, which are overridden by the forwarded headers and then by
+// the ones defined in the statement.
+func makeHeaders(statement domain.Statement, mapping restql.Mapping, queryCtx restql.QueryContext) map[string]string {
+	headers := make(map[string]string)
+	for key, value := range mapping.Headers() {
+		headers[http.CanonicalHeaderKey(key)] = value
+	}
+
+	for key, value := range getForwardHeaders(queryCtx) {
+		headers[key] = value
+	}
+
 	for key, value := range statement.Headers {
 		str, ok := value.(string)
 		if !ok {
@@ -199,12 +336,20 @@ func makeHeaders(statement domain.Statement, queryCtx restql.QueryContext) map[s
 
 	_, found := headers["Content-Type"]
 	if !found {
-		headers["Content-Type"] = "application/json"
+		headers["Content-Type"] = defaultContentType(mapping)
 	}
 
 	return headers
 }
 
+func defaultContentType(mapping restql.Mapping) string {
+	if contentType := mapping.ContentType(); contentType != "" {
+		return contentType
+	}
+
+	return "application/json"
+}
+
 func isDisallowedHeader(header string) bool {
 	for _, disallowedHeader := range disallowedHeaders {
 		if strings.EqualFold(header, disallowedHeader) {
@@ -225,7 +370,32 @@ func getForwardHeaders(queryCtx restql.QueryContext) map[string]string {
 	return r
 }
 
-func parseTimeout(defaultResourceTimeout time.Duration, statement domain.Statement) time.Duration {
+// clampTimeout limits the statement timeout to the time remaining
+// until the query deadline, since restQL discards any response
+// received after it.
+func clampTimeout(ctx context.Context, timeout time.Duration) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return timeout
+	}
+
+	remaining := time.Until(deadline)
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	if remaining < timeout {
+		return remaining
+	}
+
+	return timeout
+}
+
+func parseTimeout(defaultResourceTimeout time.Duration, statement domain.Statement, mapping restql.Mapping) time.Duration {
+	if mappingTimeout := mapping.Timeout(); mappingTimeout > 0 {
+		defaultResourceTimeout = mappingTimeout
+	}
+
 	timeout := statement.Timeout
 	if timeout == nil {
 		return defaultResourceTimeout
@@ -238,3 +408,30 @@ func parseTimeout(defaultResourceTimeout time.Duration, statement domain.Stateme
 
 	return time.Millisecond * time.Duration(duration)
 }
+
+// Formats of the header forwarding the statement deadline to upstreams.
+const (
+	DeadlineFormatMilliseconds = "milliseconds"
+	DeadlineFormatGRPC         = "grpc-timeout"
+	DeadlineFormatTimestamp    = "timestamp"
+)
+
+// MakeDeadlineHeaderValue formats the time budget of a request
+// as the remaining milliseconds, as a gRPC timeout value or as
+// the absolute deadline in milliseconds since the Unix epoch.
+func MakeDeadlineHeaderValue(format string, timeout time.Duration, now time.Time) string {
+	ms := timeout.Milliseconds()
+
+	switch format {
+	case DeadlineFormatGRPC:
+		// gRPC timeout values are limited to 8 digits
+		if ms < 100000000 {
+			return strconv.FormatInt(ms, 10) + "m"
+		}
+		return strconv.FormatInt(int64(timeout/time.Second), 10) + "S"
+	case DeadlineFormatTimestamp:
+		return strconv.FormatInt(now.Add(timeout).UnixNano()/int64(time.Millisecond), 10)
+	default:
+		return strconv.FormatInt(ms, 10)
+	}
+}
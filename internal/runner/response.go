@@ -13,48 +13,110 @@ import (
 // from the statement that should be passed
 // to the result.
 type DoneResourceOptions struct {
-	Debugging    bool
-	IgnoreErrors bool
-	MaxAge       interface{}
-	SMaxAge      interface{}
+	Debugging          bool
+	IgnoreErrors       bool
+	IgnoreErrorsStatus []int
+	SuccessOn          []int
+	ResourceName       string
+	PathParams         map[string]interface{}
+	MaxAge             interface{}
+	SMaxAge            interface{}
+
+	StaleWhileRevalidate interface{}
+	StaleIfError         interface{}
+	NoCache              bool
+	NoStore              bool
+	Private              bool
+	Public               bool
+	MustRevalidate       bool
+
+	Raw bool
 }
 
 // NewDoneResource constructs a DoneResourceOptions value.
+// A response with one of the status codes defined as success
+// by the statement is successful and has an empty body.
+// Bodies of raw statements are not required to be JSON.
 func NewDoneResource(request restql.HTTPRequest, response restql.HTTPResponse, options DoneResourceOptions) restql.DoneResource {
+	successOn := containsStatus(options.SuccessOn, response.StatusCode)
+
 	dr := restql.DoneResource{
-		Status:          response.StatusCode,
-		Success:         response.StatusCode >= 200 && response.StatusCode < 400,
-		IgnoreErrors:    options.IgnoreErrors,
-		CacheControl:    makeCacheControl(response, options),
-		Method:          request.Method,
-		URL:             response.URL,
-		RequestParams:   request.Query,
-		RequestBody:     request.Body,
-		RequestHeaders:  request.Headers,
-		ResponseHeaders: response.Headers,
-		ResponseBody:    response.Body,
-		ResponseTime:    response.Duration.Milliseconds(),
+		Status:            response.StatusCode,
+		Success:           successOn || (response.StatusCode >= 200 && response.StatusCode < 400),
+		IgnoreErrors:      ignoresErrors(options, response.StatusCode),
+		CacheControl:      makeCacheControl(response, options),
+		ResourceName:      options.ResourceName,
+		Method:            request.Method,
+		URL:               response.URL,
+		RequestPathParams: options.PathParams,
+		RequestParams:     request.Query,
+		RequestBody:       request.Body,
+		RequestHeaders:    request.Headers,
+		ResponseHeaders:   response.Headers,
+		ResponseBody:      response.Body,
+		ResponseTime:      response.Duration.Milliseconds(),
+
+		ResponseSize:           response.Size,
+		ResponseCompressedSize: response.CompressedSize,
+	}
+
+	if successOn {
+		dr.ResponseBody = restql.NewResponseBodyFromBytes(nil, nil)
+		return dr
+	}
+
+	if !options.Raw && isInvalidJSON(response.Body) {
+		dr.ErrorKind = domain.ErrorKindInvalidJSON
+		dr.ErrorMessage = "invalid json as response body"
 	}
 
 	return dr
 }
 
+// ignoresErrors returns true if the statement ignores errors
+// regardless of the status code or for the given one.
+func ignoresErrors(options DoneResourceOptions, status int) bool {
+	if !options.IgnoreErrors {
+		return false
+	}
+
+	return len(options.IgnoreErrorsStatus) == 0 || containsStatus(options.IgnoreErrorsStatus, status)
+}
+
+func containsStatus(codes []int, status int) bool {
+	for _, c := range codes {
+		if c == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isInvalidJSON(body *restql.ResponseBody) bool {
+	return body != nil && len(body.Bytes()) > 0 && !body.Valid()
+}
+
 // NewErrorResponse builds a DoneResource value for a failed HTTP call.
 func NewErrorResponse(log restql.Logger, err error, request restql.HTTPRequest, response restql.HTTPResponse, options DoneResourceOptions) restql.DoneResource {
 	rb := restql.NewResponseBodyFromValue(log, err.Error())
 
 	return restql.DoneResource{
-		Status:          response.StatusCode,
-		Success:         false,
-		IgnoreErrors:    options.IgnoreErrors,
-		ResponseBody:    rb,
-		Method:          request.Method,
-		URL:             response.URL,
-		RequestParams:   request.Query,
-		RequestBody:     request.Body,
-		RequestHeaders:  request.Headers,
-		ResponseHeaders: response.Headers,
-		ResponseTime:    response.Duration.Milliseconds(),
+		Status:            response.StatusCode,
+		Success:           false,
+		IgnoreErrors:      ignoresErrors(options, response.StatusCode),
+		ResponseBody:      rb,
+		ErrorKind:         domain.ErrorKindOf(err),
+		ErrorMessage:      err.Error(),
+		ResourceName:      options.ResourceName,
+		Method:            request.Method,
+		URL:               response.URL,
+		RequestPathParams: options.PathParams,
+		RequestParams:     request.Query,
+		RequestBody:       request.Body,
+		RequestHeaders:    request.Headers,
+		ResponseHeaders:   response.Headers,
+		ResponseTime:      response.Duration.Milliseconds(),
 	}
 }
 
@@ -75,7 +137,7 @@ func NewEmptyChainedResponse(log restql.Logger, params []string, options DoneRes
 	return restql.DoneResource{
 		Status:       400,
 		Success:      false,
-		IgnoreErrors: options.IgnoreErrors,
+		IgnoreErrors: ignoresErrors(options, 400),
 		ResponseBody: rb,
 	}
 }
@@ -127,7 +189,25 @@ func NewNewDependsOnUnresolvedResponse(log restql.Logger, stmt domain.Statement,
 	return restql.DoneResource{
 		Status:       400,
 		Success:      false,
-		IgnoreErrors: options.IgnoreErrors,
+		IgnoreErrors: ignoresErrors(options, 400),
+		ResponseBody: rb,
+	}
+}
+
+// NewFailFastSkippedResponse builds a DoneResource for a statement
+// skipped because the query failed fast on another statement.
+func NewFailFastSkippedResponse(log restql.Logger, failed domain.ResourceID) restql.DoneResource {
+	var buf bytes.Buffer
+
+	buf.WriteString("The request was skipped due to the failure of { ")
+	buf.WriteString(string(failed))
+	buf.WriteString(" }")
+
+	rb := restql.NewResponseBodyFromValue(log, buf.String())
+	return restql.DoneResource{
+		Status:       0,
+		Success:      false,
+		Skipped:      true,
 		ResponseBody: rb,
 	}
 }
@@ -136,10 +216,6 @@ func makeCacheControl(response restql.HTTPResponse, options DoneResourceOptions)
 	headerCacheControl, headerFound := getCacheControlOptionsFromHeader(response)
 	defaultCacheControl, defaultFound := getDefaultCacheControlOptions(options)
 
-	if !headerFound && !defaultFound {
-		return restql.ResourceCacheControl{}
-	}
-
 	switch {
 	case !headerFound && !defaultFound:
 		return restql.ResourceCacheControl{}
@@ -153,17 +229,20 @@ func makeCacheControl(response restql.HTTPResponse, options DoneResourceOptions)
 }
 
 func bestCacheControl(first restql.ResourceCacheControl, second restql.ResourceCacheControl) restql.ResourceCacheControl {
-	result := restql.ResourceCacheControl{}
-
-	if first.NoCache || second.NoCache {
-		result.NoCache = true
-		return result
+	result := restql.ResourceCacheControl{
+		NoCache:        first.NoCache || second.NoCache,
+		NoStore:        first.NoStore || second.NoStore,
+		Private:        first.Private || second.Private,
+		Public:         first.Public || second.Public,
+		MustRevalidate: first.MustRevalidate || second.MustRevalidate,
 	}
 
 	result.MaxAge = bestCacheControlValue(first.MaxAge, second.MaxAge)
 	result.SMaxAge = bestCacheControlValue(first.SMaxAge, second.SMaxAge)
+	result.StaleWhileRevalidate = bestCacheControlValue(first.StaleWhileRevalidate, second.StaleWhileRevalidate)
+	result.StaleIfError = bestCacheControlValue(first.StaleIfError, second.StaleIfError)
 
-	return result
+	return normalizeCacheControl(result)
 }
 
 func bestCacheControlValue(first restql.ResourceCacheControlValue, second restql.ResourceCacheControlValue) restql.ResourceCacheControlValue {
@@ -180,6 +259,25 @@ func bestCacheControlValue(first restql.ResourceCacheControlValue, second restql
 	}
 }
 
+// normalizeCacheControl applies the precedence between directives:
+// `no-store` and `no-cache` discard any expiration directive
+// and `private` overrides `public`.
+func normalizeCacheControl(cc restql.ResourceCacheControl) restql.ResourceCacheControl {
+	if cc.NoStore || cc.NoCache {
+		return restql.ResourceCacheControl{
+			NoCache: cc.NoCache,
+			NoStore: cc.NoStore,
+			Private: cc.Private,
+		}
+	}
+
+	if cc.Private {
+		cc.Public = false
+	}
+
+	return cc
+}
+
 func min(a int, b int) int {
 	if a < b {
 		return a
@@ -201,7 +299,28 @@ func getDefaultCacheControlOptions(options DoneResourceOptions) (cc restql.Resou
 		cc.SMaxAge = restql.ResourceCacheControlValue{Exist: true, Time: smaxAge}
 	}
 
-	return cc, found
+	staleWhileRevalidate, ok := options.StaleWhileRevalidate.(int)
+	if ok {
+		found = true
+		cc.StaleWhileRevalidate = restql.ResourceCacheControlValue{Exist: true, Time: staleWhileRevalidate}
+	}
+
+	staleIfError, ok := options.StaleIfError.(int)
+	if ok {
+		found = true
+		cc.StaleIfError = restql.ResourceCacheControlValue{Exist: true, Time: staleIfError}
+	}
+
+	if options.NoCache || options.NoStore || options.Private || options.Public || options.MustRevalidate {
+		found = true
+		cc.NoCache = options.NoCache
+		cc.NoStore = options.NoStore
+		cc.Private = options.Private
+		cc.Public = options.Public
+		cc.MustRevalidate = options.MustRevalidate
+	}
+
+	return normalizeCacheControl(cc), found
 }
 
 func isComma(r rune) bool {
@@ -230,37 +349,70 @@ func getCacheControlOptionsFromHeader(response restql.HTTPResponse) (cc restql.R
 	for _, ccField := range cacheControlFields {
 		ccField = strings.TrimSpace(ccField)
 
-		if strings.EqualFold(ccField, "no-cache") {
-			return restql.ResourceCacheControl{NoCache: true}, true
-		}
-
-		keyValue := strings.Split(ccField, "=")
-		if len(keyValue) < 2 {
-			continue
+		key, value := ccField, ""
+		if idx := strings.Index(ccField, "="); idx >= 0 {
+			key, value = ccField[:idx], strings.Trim(ccField[idx+1:], `"`)
 		}
 
-		key, value := keyValue[0], keyValue[1]
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "no-cache":
+			found = true
+			cc.NoCache = true
+		case "no-store":
+			found = true
+			cc.NoStore = true
+		case "private":
+			found = true
+			cc.Private = true
+		case "public":
+			found = true
+			cc.Public = true
+		case "must-revalidate":
+			found = true
+			cc.MustRevalidate = true
+		case "max-age":
+			timeValue, ok := parseCacheControlTime(value)
+			if !ok {
+				continue
+			}
 
-		if strings.EqualFold(key, "max-age") {
-			timeValue, err := strconv.Atoi(value)
-			if err != nil {
+			found = true
+			cc.MaxAge = timeValue
+		case "s-maxage":
+			timeValue, ok := parseCacheControlTime(value)
+			if !ok {
 				continue
 			}
 
 			found = true
-			cc.MaxAge = restql.ResourceCacheControlValue{Exist: true, Time: timeValue}
-		}
+			cc.SMaxAge = timeValue
+		case "stale-while-revalidate":
+			timeValue, ok := parseCacheControlTime(value)
+			if !ok {
+				continue
+			}
 
-		if strings.EqualFold(key, "s-maxage") {
-			timeValue, err := strconv.Atoi(value)
-			if err != nil {
+			found = true
+			cc.StaleWhileRevalidate = timeValue
+		case "stale-if-error":
+			timeValue, ok := parseCacheControlTime(value)
+			if !ok {
 				continue
 			}
 
 			found = true
-			cc.SMaxAge = restql.ResourceCacheControlValue{Exist: true, Time: timeValue}
+			cc.StaleIfError = timeValue
 		}
 	}
 
-	return cc, found
+	return normalizeCacheControl(cc), found
+}
+
+func parseCacheControlTime(value string) (restql.ResourceCacheControlValue, bool) {
+	timeValue, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return restql.ResourceCacheControlValue{}, false
+	}
+
+	return restql.ResourceCacheControlValue{Exist: true, Time: timeValue}, true
 }
@@ -32,6 +32,19 @@ func TestNewDoneResource(t *testing.T) {
 			runner.DoneResourceOptions{},
 			restql.DoneResource{Status: 400, Success: false, IgnoreErrors: false, ResponseBody: nil},
 		},
+		{
+			"should create done resource with error kind for invalid json body",
+			restql.HTTPRequest{},
+			restql.HTTPResponse{StatusCode: 200, Body: restql.NewResponseBodyFromBytes(test.NoOpLogger, []byte("<html></html>"))},
+			runner.DoneResourceOptions{},
+			restql.DoneResource{
+				Status:       200,
+				Success:      true,
+				ErrorKind:    domain.ErrorKindInvalidJSON,
+				ErrorMessage: "invalid json as response body",
+				ResponseBody: restql.NewResponseBodyFromBytes(test.NoOpLogger, []byte("<html></html>")),
+			},
+		},
 		{
 			"should create done resource with debug",
 			restql.HTTPRequest{
@@ -73,6 +86,27 @@ func TestNewDoneResource(t *testing.T) {
 				ResponseBody: nil,
 			},
 		},
+		{
+			"should create done resource ignoring errors for status code",
+			restql.HTTPRequest{},
+			restql.HTTPResponse{StatusCode: 404, Body: nil},
+			runner.DoneResourceOptions{IgnoreErrors: true, IgnoreErrorsStatus: []int{404, 409}},
+			restql.DoneResource{Status: 404, Success: false, IgnoreErrors: true, ResponseBody: nil},
+		},
+		{
+			"should create done resource not ignoring errors for other status code",
+			restql.HTTPRequest{},
+			restql.HTTPResponse{StatusCode: 500, Body: nil},
+			runner.DoneResourceOptions{IgnoreErrors: true, IgnoreErrorsStatus: []int{404, 409}},
+			restql.DoneResource{Status: 500, Success: false, IgnoreErrors: false, ResponseBody: nil},
+		},
+		{
+			"should create empty successful done resource for success on status code",
+			restql.HTTPRequest{},
+			restql.HTTPResponse{StatusCode: 404, Body: restql.NewResponseBodyFromBytes(test.NoOpLogger, []byte(`{"message": "not found"}`))},
+			runner.DoneResourceOptions{SuccessOn: []int{404}},
+			restql.DoneResource{Status: 404, Success: true, ResponseBody: restql.NewResponseBodyFromBytes(nil, nil)},
+		},
 		{
 			"should create done resource with cache control information returned by resource",
 			restql.HTTPRequest{},
@@ -170,6 +204,61 @@ func TestNewDoneResource(t *testing.T) {
 				ResponseBody:    nil,
 			},
 		},
+		{
+			"should create done resource with all cache control directives returned by resource",
+			restql.HTTPRequest{},
+			restql.HTTPResponse{StatusCode: 200, Body: nil, Headers: map[string]string{"Cache-Control": "Public, max-age=400, must-revalidate, stale-while-revalidate=30, stale-if-error=\"600\""}},
+			runner.DoneResourceOptions{},
+			restql.DoneResource{
+				Status:  200,
+				Success: true,
+				CacheControl: restql.ResourceCacheControl{
+					Public:               true,
+					MustRevalidate:       true,
+					MaxAge:               restql.ResourceCacheControlValue{Exist: true, Time: 400},
+					StaleWhileRevalidate: restql.ResourceCacheControlValue{Exist: true, Time: 30},
+					StaleIfError:         restql.ResourceCacheControlValue{Exist: true, Time: 600},
+				},
+				ResponseHeaders: map[string]string{"Cache-Control": "Public, max-age=400, must-revalidate, stale-while-revalidate=30, stale-if-error=\"600\""},
+				IgnoreErrors:    false,
+				ResponseBody:    nil,
+			},
+		},
+		{
+			"should create done resource with no-store overriding expiration directives returned by resource",
+			restql.HTTPRequest{},
+			restql.HTTPResponse{StatusCode: 200, Body: nil, Headers: map[string]string{"Cache-Control": "private, no-store, max-age=400"}},
+			runner.DoneResourceOptions{},
+			restql.DoneResource{
+				Status:  200,
+				Success: true,
+				CacheControl: restql.ResourceCacheControl{
+					NoStore: true,
+					Private: true,
+				},
+				ResponseHeaders: map[string]string{"Cache-Control": "private, no-store, max-age=400"},
+				IgnoreErrors:    false,
+				ResponseBody:    nil,
+			},
+		},
+		{
+			"should create done resource with private defined in statement overriding public returned by resource",
+			restql.HTTPRequest{},
+			restql.HTTPResponse{StatusCode: 200, Body: nil, Headers: map[string]string{"Cache-Control": "public, max-age=400, stale-if-error=600"}},
+			runner.DoneResourceOptions{Private: true, StaleIfError: 300},
+			restql.DoneResource{
+				Status:  200,
+				Success: true,
+				CacheControl: restql.ResourceCacheControl{
+					Private:      true,
+					MaxAge:       restql.ResourceCacheControlValue{Exist: true, Time: 400},
+					StaleIfError: restql.ResourceCacheControlValue{Exist: true, Time: 300},
+				},
+				ResponseHeaders: map[string]string{"Cache-Control": "public, max-age=400, stale-if-error=600"},
+				IgnoreErrors:    false,
+				ResponseBody:    nil,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -216,6 +305,8 @@ func TestNewTimeoutResponse(t *testing.T) {
 				RequestParams:  map[string]interface{}{"id": "123456"},
 				ResponseTime:   100,
 				ResponseBody:   restql.NewResponseBodyFromValue(test.NoOpLogger, timeoutErr.Error()),
+				ErrorKind:      domain.ErrorKindTimeout,
+				ErrorMessage:   "request timed out",
 			},
 		},
 		{
@@ -242,6 +333,8 @@ func TestNewTimeoutResponse(t *testing.T) {
 				RequestParams:  map[string]interface{}{"id": "123456"},
 				ResponseTime:   100,
 				ResponseBody:   restql.NewResponseBodyFromValue(test.NoOpLogger, timeoutErr.Error()),
+				ErrorKind:      domain.ErrorKindTimeout,
+				ErrorMessage:   "request timed out",
 			},
 		},
 	}
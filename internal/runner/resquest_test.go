@@ -1,8 +1,11 @@
 package runner_test
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v6/internal/runner"
@@ -47,6 +50,18 @@ func TestMakeRequest(t *testing.T) {
 			restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, "http://hero.io/api")}},
 			restql.HTTPRequest{Method: http.MethodDelete, Schema: "http", Host: "hero.io", Path: "/api", Query: map[string]interface{}{}, Headers: map[string]string{"Content-Type": "application/json"}},
 		},
+		{
+			"should make head request with query params",
+			domain.Statement{Method: domain.CheckMethod, Resource: "hero", With: domain.Params{Values: map[string]interface{}{"id": "123456"}}},
+			restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, "http://hero.io/api")}},
+			restql.HTTPRequest{Method: http.MethodHead, Schema: "http", Host: "hero.io", Path: "/api", Query: map[string]interface{}{"id": "123456"}, Headers: map[string]string{"Content-Type": "application/json"}},
+		},
+		{
+			"should make options request with url",
+			domain.Statement{Method: domain.OptionsMethod, Resource: "hero"},
+			restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, "http://hero.io/api")}},
+			restql.HTTPRequest{Method: http.MethodOptions, Schema: "http", Host: "hero.io", Path: "/api", Query: map[string]interface{}{}, Headers: map[string]string{"Content-Type": "application/json"}},
+		},
 		{
 			"should make post request with body using only resolved values",
 			domain.Statement{Method: domain.ToMethod, Resource: "hero", With: domain.Params{Values: map[string]interface{}{"id": 1, "name": domain.Variable{Target: "name"}}}},
@@ -110,6 +125,18 @@ func TestMakeRequest(t *testing.T) {
 			restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, "http://hero.io/api")}},
 			restql.HTTPRequest{Method: http.MethodPost, Schema: "http", Host: "hero.io", Path: "/api", Query: map[string]interface{}{}, Body: []interface{}{"1", "2", "3"}, Headers: map[string]string{"Content-Type": "application/json"}},
 		},
+		{
+			"should make post request with parameter as form",
+			domain.Statement{Method: domain.ToMethod, Resource: "hero", With: domain.Params{Values: map[string]interface{}{"hero": domain.AsForm{Value: map[string]interface{}{"name": "batman"}}}}},
+			restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, "http://hero.io/api")}},
+			restql.HTTPRequest{Method: http.MethodPost, Schema: "http", Host: "hero.io", Path: "/api", Query: map[string]interface{}{}, Body: map[string]interface{}{"name": "batman"}, Headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded"}},
+		},
+		{
+			"should make post request with body as multipart form",
+			domain.Statement{Method: domain.ToMethod, Resource: "hero", With: domain.Params{Body: domain.AsMultipart{Value: map[string]interface{}{"name": "batman"}}}},
+			restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, "http://hero.io/api")}},
+			restql.HTTPRequest{Method: http.MethodPost, Schema: "http", Host: "hero.io", Path: "/api", Query: map[string]interface{}{}, Body: map[string]interface{}{"name": "batman"}, Headers: map[string]string{"Content-Type": "multipart/form-data"}},
+		},
 		{
 			"should make request with case-insensitive merged headers",
 			domain.Statement{Method: domain.FromMethod, Resource: "hero", Headers: map[string]interface{}{"X-TID": "1234567890", "accept": "application/json"}},
@@ -128,19 +155,171 @@ func TestMakeRequest(t *testing.T) {
 			restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, "http://hero.io/api")}},
 			restql.HTTPRequest{Method: http.MethodPost, Schema: "http", Host: "hero.io", Path: "/api", Query: map[string]interface{}{"context": "something"}, Body: map[string]interface{}{"id": 1}, Headers: map[string]string{"Content-Type": "application/json"}},
 		},
+		{
+			"should make request with mapping default headers and content type",
+			domain.Statement{Method: domain.FromMethod, Resource: "hero"},
+			restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, `{"url": "http://hero.io/api", "headers": {"x-api-key": "abc"}, "contentType": "application/vnd.hero+json"}`)}},
+			restql.HTTPRequest{Method: http.MethodGet, Schema: "http", Host: "hero.io", Path: "/api", Query: map[string]interface{}{}, Headers: map[string]string{"X-Api-Key": "abc", "Content-Type": "application/vnd.hero+json"}},
+		},
+		{
+			"should make request with mapping default headers overridden by statement",
+			domain.Statement{Method: domain.FromMethod, Resource: "hero", Headers: map[string]interface{}{"X-Api-Key": "xyz", "Content-Type": "text/plain"}},
+			restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, `{"url": "http://hero.io/api", "headers": {"x-api-key": "abc", "accept": "*/*"}, "contentType": "application/vnd.hero+json"}`)}},
+			restql.HTTPRequest{Method: http.MethodGet, Schema: "http", Host: "hero.io", Path: "/api", Query: map[string]interface{}{}, Headers: map[string]string{"X-Api-Key": "xyz", "Accept": "*/*", "Content-Type": "text/plain"}},
+		},
+		{
+			"should make from request with method and body overridden by mapping",
+			domain.Statement{Method: domain.FromMethod, Resource: "hero", With: domain.Params{Values: map[string]interface{}{"id": "1", "name": "batman", "page": domain.AsQuery{Value: 2}}}},
+			restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, `{"url": "http://hero.io/api/:id/search", "overrides": {"from": {"method": "post", "body": true}}}`)}},
+			restql.HTTPRequest{Method: http.MethodPost, Schema: "http", Host: "hero.io", Path: "/api/1/search", Query: map[string]interface{}{"page": 2}, Body: map[string]interface{}{"name": "batman"}, Headers: map[string]string{"Content-Type": "application/json"}},
+		},
+		{
+			"should make from request with method overridden by mapping",
+			domain.Statement{Method: domain.FromMethod, Resource: "hero", With: domain.Params{Values: map[string]interface{}{"name": "batman"}}},
+			restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, `{"url": "http://hero.io/api", "overrides": {"from": {"method": "QUERY"}}}`)}},
+			restql.HTTPRequest{Method: "QUERY", Schema: "http", Host: "hero.io", Path: "/api", Query: map[string]interface{}{"name": "batman"}, Headers: map[string]string{"Content-Type": "application/json"}},
+		},
+		{
+			"should not override method of other statement methods",
+			domain.Statement{Method: domain.DeleteMethod, Resource: "hero", With: domain.Params{Values: map[string]interface{}{"name": "batman"}}},
+			restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, `{"url": "http://hero.io/api", "overrides": {"from": {"method": "post", "body": true}}}`)}},
+			restql.HTTPRequest{Method: http.MethodDelete, Schema: "http", Host: "hero.io", Path: "/api", Query: map[string]interface{}{"name": "batman"}, Headers: map[string]string{"Content-Type": "application/json"}},
+		},
 	}
 
 	forwardPrefix := "c_"
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := runner.MakeRequest(0, forwardPrefix, tt.statement, tt.queryCtx)
+			got := runner.MakeRequest(context.Background(), 0, forwardPrefix, tt.statement, tt.queryCtx)
+
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
+func TestMakeRequestTimeout(t *testing.T) {
+	statement := domain.Statement{Method: domain.FromMethod, Resource: "hero", Timeout: 2000}
+	queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, "http://hero.io/api")}}
+
+	got := runner.MakeRequest(context.Background(), time.Second, "", statement, queryCtx)
+	test.Equal(t, got.Timeout, 2*time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	got = runner.MakeRequest(ctx, time.Second, "", statement, queryCtx)
+	test.Equal(t, got.Timeout > 0 && got.Timeout <= 500*time.Millisecond, true)
+
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	got = runner.MakeRequest(expired, time.Second, "", statement, queryCtx)
+	test.Equal(t, got.Timeout, time.Duration(0))
+
+	queryCtx = restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, `{"url": "http://hero.io/api", "timeout": "300ms"}`)}}
+
+	got = runner.MakeRequest(context.Background(), time.Second, "", domain.Statement{Method: domain.FromMethod, Resource: "hero"}, queryCtx)
+	test.Equal(t, got.Timeout, 300*time.Millisecond)
+
+	got = runner.MakeRequest(context.Background(), time.Second, "", statement, queryCtx)
+	test.Equal(t, got.Timeout, 2*time.Second)
+}
+
+func TestMakeDeadlineHeaderValue(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+
+	tests := []struct {
+		name     string
+		format   string
+		timeout  time.Duration
+		expected string
+	}{
+		{"should format as milliseconds by default", "", 1500 * time.Millisecond, "1500"},
+		{"should format as milliseconds", runner.DeadlineFormatMilliseconds, 250 * time.Millisecond, "250"},
+		{"should format as grpc timeout", runner.DeadlineFormatGRPC, 250 * time.Millisecond, "250m"},
+		{"should format long grpc timeout in seconds", runner.DeadlineFormatGRPC, 30 * time.Hour, "108000S"},
+		{"should format as absolute timestamp", runner.DeadlineFormatTimestamp, 250 * time.Millisecond, "1600000000250"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runner.MakeDeadlineHeaderValue(tt.format, tt.timeout, now)
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
 
+func TestMakePathParams(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement domain.Statement
+		mapping   restql.Mapping
+		expected  map[string]interface{}
+	}{
+		{
+			"should return nil when mapping has no path params",
+			domain.Statement{Method: domain.FromMethod, Resource: "hero", With: domain.Params{Values: map[string]interface{}{"id": 1}}},
+			mapping(t, "http://hero.io/api"),
+			nil,
+		},
+		{
+			"should return only values used as path params",
+			domain.Statement{Method: domain.FromMethod, Resource: "hero", With: domain.Params{Values: map[string]interface{}{"id": 1, "name": "batman"}}},
+			mapping(t, "http://hero.io/api/:id"),
+			map[string]interface{}{"id": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runner.MakePathParams(tt.statement, tt.mapping)
 			test.Equal(t, got, tt.expected)
 		})
 	}
 }
 
+func TestValidateFormBody(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement domain.Statement
+		expected  error
+	}{
+		{
+			"should accept object as form",
+			domain.Statement{Method: domain.ToMethod, Resource: "hero", With: domain.Params{Body: domain.AsForm{Value: map[string]interface{}{"name": "batman"}}}},
+			nil,
+		},
+		{
+			"should accept encoded string as form",
+			domain.Statement{Method: domain.ToMethod, Resource: "hero", With: domain.Params{Body: domain.AsForm{Value: "name=batman"}}},
+			nil,
+		},
+		{
+			"should accept list when not sent as form",
+			domain.Statement{Method: domain.ToMethod, Resource: "hero", With: domain.Params{Body: []interface{}{"batman"}}},
+			nil,
+		},
+		{
+			"should reject list as form",
+			domain.Statement{Method: domain.ToMethod, Resource: "hero", With: domain.Params{Body: domain.AsForm{Value: []interface{}{"batman"}}}},
+			runner.ErrInvalidFormBody,
+		},
+		{
+			"should reject parameter list as multipart form",
+			domain.Statement{Method: domain.ToMethod, Resource: "hero", With: domain.Params{Values: map[string]interface{}{"heroes": domain.AsMultipart{Value: []interface{}{"batman"}}}}},
+			runner.ErrInvalidFormBody,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runner.ValidateFormBody(tt.statement, mapping(t, "http://hero.io/api"))
+			test.Equal(t, errors.Is(err, tt.expected), true)
+		})
+	}
+}
+
 func mapping(t *testing.T, url string) restql.Mapping {
 	m, err := restql.NewMapping("test-resource", url)
 	if err != nil {
@@ -0,0 +1,42 @@
+package runner
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// isRetriable tells if the request of the attempt can be sent
+// again after its outcome. Only idempotent methods are retried,
+// taken from the request since the mapping can override the one
+// implied by the statement.
+func isRetriable(a attempt) bool {
+	switch a.request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return isEndpointFailure(a)
+	default:
+		return false
+	}
+}
+
+// waitBackoff waits the given duration before a retry,
+// returning false if the context is done in the meantime.
+func waitBackoff(ctx context.Context, backoff time.Duration) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if backoff <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -2,7 +2,7 @@ package runner
 
 import (
 	"context"
-	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
@@ -22,6 +22,22 @@ var (
 	// ErrMaxGoroutineDenied represents the event when restQL reaches the maximum
 	// number of concurrent goroutines and cannot process anymore.
 	ErrMaxGoroutineDenied = errors.New("max concurrent goroutine reached: statement execution denied")
+
+	// ErrTenantQueryQuotaDenied represents the event when a tenant reaches
+	// its quota of concurrent queries and cannot process anymore.
+	ErrTenantQueryQuotaDenied = errors.New("tenant concurrent query quota reached: query execution denied")
+
+	// ErrNamespaceQueryQuotaDenied represents the event when a namespace reaches
+	// its quota of concurrent queries and cannot process anymore.
+	ErrNamespaceQueryQuotaDenied = errors.New("namespace concurrent query quota reached: query execution denied")
+
+	// ErrTenantGoroutineQuotaDenied represents the event when a tenant reaches
+	// its quota of concurrent goroutines and cannot process anymore.
+	ErrTenantGoroutineQuotaDenied = errors.New("tenant concurrent goroutine quota reached: statement execution denied")
+
+	// ErrNamespaceGoroutineQuotaDenied represents the event when a namespace reaches
+	// its quota of concurrent goroutines and cannot process anymore.
+	ErrNamespaceGoroutineQuotaDenied = errors.New("namespace concurrent goroutine quota reached: statement execution denied")
 )
 
 // Options wraps all configuration parameters for the Runner
@@ -29,6 +45,46 @@ type Options struct {
 	GlobalQueryTimeout      time.Duration
 	MaxConcurrentQueries    int
 	MaxConcurrentGoroutines int
+	WorkerPoolSize          int
+	MaxQueueWait            time.Duration
+	MaxQueueLength          int
+	TenantQuotas            map[string]Quota
+	NamespaceQuotas         map[string]Quota
+	AdaptiveLimit           AdaptiveLimitOptions
+	FailFast                bool
+}
+
+// LimiterStatus reports the current state of the global query limiter.
+// A zero limit means the number of concurrent queries is not limited.
+type LimiterStatus struct {
+	Adaptive   bool `json:"adaptive"`
+	Limit      int  `json:"limit"`
+	InFlight   int  `json:"inFlight"`
+	QueueDepth int  `json:"queueDepth"`
+}
+
+// Quota defines the concurrency limits of a tenant or namespace,
+// accounted separately from the global ones.
+type Quota struct {
+	MaxConcurrentQueries    int
+	MaxConcurrentGoroutines int
+}
+
+type quotaLimiters struct {
+	queries    *limiter
+	goroutines *limiter
+}
+
+func newQuotaLimiters(quotas map[string]Quota, maxQueueLength int) map[string]quotaLimiters {
+	result := make(map[string]quotaLimiters, len(quotas))
+	for name, q := range quotas {
+		result[name] = quotaLimiters{
+			queries:    newQueueingLimiter(int32(q.MaxConcurrentQueries), maxQueueLength),
+			goroutines: newLimiter(int32(q.MaxConcurrentGoroutines)),
+		}
+	}
+
+	return result
 }
 
 // Runner process a query into a Resource collection
@@ -39,17 +95,38 @@ type Runner struct {
 	log              restql.Logger
 	executor         Executor
 	queryLimiter     *limiter
+	adaptiveLimit    *adaptiveLimit
 	goroutineLimiter *limiter
+	pool             *workerPool
+	tenantQuotas     map[string]quotaLimiters
+	namespaceQuotas  map[string]quotaLimiters
 	options          Options
 }
 
 // NewRunner returns a Runner instance.
 func NewRunner(log restql.Logger, executor Executor, options Options) Runner {
+	queryLimiter := newQueueingLimiter(int32(options.MaxConcurrentQueries), options.MaxQueueLength)
+
+	var al *adaptiveLimit
+	if options.AdaptiveLimit.Enable {
+		adaptiveOptions := options.AdaptiveLimit
+		if adaptiveOptions.MaxLimit <= 0 {
+			adaptiveOptions.MaxLimit = options.MaxConcurrentQueries
+		}
+
+		al = newAdaptiveLimit(adaptiveOptions, options.MaxQueueLength)
+		queryLimiter = al.limiter
+	}
+
 	return Runner{
 		log:              log,
 		executor:         executor,
-		queryLimiter:     newLimiter(int32(options.MaxConcurrentQueries)),
+		queryLimiter:     queryLimiter,
+		adaptiveLimit:    al,
 		goroutineLimiter: newLimiter(int32(options.MaxConcurrentGoroutines)),
+		pool:             newWorkerPool(options.WorkerPoolSize),
+		tenantQuotas:     newQuotaLimiters(options.TenantQuotas, options.MaxQueueLength),
+		namespaceQuotas:  newQuotaLimiters(options.NamespaceQuotas, options.MaxQueueLength),
 		options:          options,
 	}
 }
@@ -58,12 +135,6 @@ func NewRunner(log restql.Logger, executor Executor, options Options) Runner {
 func (r Runner) ExecuteQuery(ctx context.Context, query domain.Query, queryCtx restql.QueryContext) (domain.Resources, error) {
 	log := restql.GetLogger(ctx)
 
-	success := r.queryLimiter.Acquire()
-	if !success {
-		return nil, ErrMaxQueryDenied
-	}
-	defer r.queryLimiter.Release()
-
 	var cancel context.CancelFunc
 	queryTimeout, ok := r.parseQueryTimeout(query)
 	if ok {
@@ -73,52 +144,110 @@ func (r Runner) ExecuteQuery(ctx context.Context, query domain.Query, queryCtx r
 	}
 	defer cancel()
 
-	resources, err := r.initializeResources(query)
+	queryQuota, goroutineQuota := r.makeQuotas(queryCtx.Options)
+
+	queueWait, err := r.acquireQueryQuota(ctx, queryQuota, queryCtx.Options)
 	if err != nil {
+		log.Info("query execution denied", "error", err, "tenant", queryCtx.Options.Tenant, "namespace", queryCtx.Options.Namespace,
+			"queue-wait-ms", queueWait.Milliseconds(), "queue-depth", r.queryLimiter.QueueLength())
 		return nil, err
 	}
+	defer queryQuota.Release()
 
-	state := NewState(resources)
+	if queueWait > 0 {
+		log.Debug("query waited for execution", "queue-wait-ms", queueWait.Milliseconds(), "queue-depth", r.queryLimiter.QueueLength())
+	}
 
-	requestCh := make(chan request, 10)
-	resultCh := make(chan result, 10)
-	outputCh := make(chan domain.Resources)
-	errorCh := make(chan error)
+	start := time.Now()
 
-	stateWorker := &stateWorker{
-		log:              log,
-		requestCh:        requestCh,
-		resultCh:         resultCh,
-		outputCh:         outputCh,
-		errorCh:          errorCh,
-		state:            state,
-		ctx:              ctx,
-		goroutineLimiter: r.goroutineLimiter,
-	}
-
-	requestWorker := &requestWorker{
-		requestCh:        requestCh,
-		resultCh:         resultCh,
-		errorCh:          errorCh,
-		executor:         r.executor,
-		queryCtx:         queryCtx,
-		ctx:              ctx,
-		goroutineLimiter: r.goroutineLimiter,
-	}
-
-	go stateWorker.Run()
-	go requestWorker.Run()
-
-	select {
-	case output := <-outputCh:
-		return output, nil
-	case err := <-errorCh:
-		log.Debug("an error occurred when running the query", "error", err)
+	resources, err := r.initializeResources(query)
+	if err != nil {
 		return nil, err
-	case <-ctx.Done():
+	}
+
+	e := &execution{
+		log:            log,
+		ctx:            ctx,
+		cancel:         cancel,
+		failFast:       r.options.FailFast || isFailFastEnabled(query),
+		executor:       r.executor,
+		queryCtx:       queryCtx,
+		pool:           r.pool,
+		goroutineQuota: goroutineQuota,
+		state:          NewState(resources),
+		results:        make(chan result, len(resources)),
+	}
+
+	output, err := e.Run()
+	switch {
+	case err == ErrQueryTimedOut:
 		log.Debug("query timed out")
-		return nil, ErrQueryTimedOut
+	case err != nil:
+		log.Debug("an error occurred when running the query", "error", err, "tenant", queryCtx.Options.Tenant, "namespace", queryCtx.Options.Namespace)
+	}
+
+	r.observeQuery(start, err)
+	return output, err
+}
+
+// LimiterStatus returns the current state of the global query limiter.
+func (r Runner) LimiterStatus() LimiterStatus {
+	limit, inFlight := r.queryLimiter.Limit()
+
+	return LimiterStatus{
+		Adaptive:   r.adaptiveLimit != nil,
+		Limit:      limit,
+		InFlight:   inFlight,
+		QueueDepth: r.queryLimiter.QueueLength(),
+	}
+}
+
+func (r Runner) observeQuery(start time.Time, err error) {
+	if r.adaptiveLimit == nil {
+		return
+	}
+
+	r.adaptiveLimit.Observe(start, err)
+}
+
+// makeQuotas builds the query and goroutine quotas applied to a query,
+// composed by the ones defined for its tenant and namespace and the
+// global limiters. The scoped quotas are acquired first, so a query
+// waiting on a saturated tenant does not hold a global slot.
+func (r Runner) makeQuotas(options restql.QueryOptions) (queryQuota quota, goroutineQuota quota) {
+	if tq, ok := r.tenantQuotas[options.Tenant]; ok {
+		queryQuota.add(tq.queries, ErrTenantQueryQuotaDenied)
+		goroutineQuota.add(tq.goroutines, ErrTenantGoroutineQuotaDenied)
+	}
+
+	if nq, ok := r.namespaceQuotas[options.Namespace]; ok && options.Namespace != "" {
+		queryQuota.add(nq.queries, ErrNamespaceQueryQuotaDenied)
+		goroutineQuota.add(nq.goroutines, ErrNamespaceGoroutineQuotaDenied)
 	}
+
+	queryQuota.add(r.queryLimiter, ErrMaxQueryDenied)
+	goroutineQuota.add(r.goroutineLimiter, ErrMaxGoroutineDenied)
+
+	return queryQuota, goroutineQuota
+}
+
+// acquireQueryQuota reserves the query quota, waiting for it up to
+// the maximum queue wait, if defined, or the context deadline.
+// Saved queries are given priority over ad-hoc ones while waiting.
+func (r Runner) acquireQueryQuota(ctx context.Context, queryQuota quota, options restql.QueryOptions) (time.Duration, error) {
+	if r.options.MaxQueueWait <= 0 {
+		return 0, queryQuota.Acquire()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.options.MaxQueueWait)
+	defer cancel()
+
+	priority := adHocQueryPriority
+	if options.Id != "" {
+		priority = savedQueryPriority
+	}
+
+	return queryQuota.Wait(ctx, priority)
 }
 
 func (r Runner) parseQueryTimeout(query domain.Query) (time.Duration, bool) {
@@ -155,232 +284,246 @@ func (r Runner) initializeResources(query domain.Query) (domain.Resources, error
 	return resources, nil
 }
 
-type request struct {
-	ResourceIdentifier domain.ResourceID
-	Statement          interface{}
-}
-
 type result struct {
 	ResourceIdentifier domain.ResourceID
 	Response           interface{}
 }
 
-type stateWorker struct {
-	log              restql.Logger
-	requestCh        chan request
-	resultCh         chan result
-	outputCh         chan domain.Resources
-	errorCh          chan error
-	state            *State
-	ctx              context.Context
-	goroutineLimiter *limiter
+// execution resolves the statements of a single query, dispatching
+// their HTTP calls to the shared worker pool as soon as their
+// dependencies are done and collecting the responses on the
+// calling goroutine.
+type execution struct {
+	log            restql.Logger
+	ctx            context.Context
+	cancel         context.CancelFunc
+	failFast       bool
+	executor       Executor
+	queryCtx       restql.QueryContext
+	pool           *workerPool
+	goroutineQuota quota
+	state          *State
+	results        chan result
 }
 
-func (sw *stateWorker) Run() {
-	for !sw.state.HasFinished() {
-		availableResources := sw.state.Available()
+// Run executes the query until all statements are done, the
+// context is done or a statement execution is denied.
+func (e *execution) Run() (domain.Resources, error) {
+	for !e.state.HasFinished() {
+		availableResources := e.state.Available()
 		for resourceID := range availableResources {
-			sw.state.SetAsRequest(resourceID)
+			e.state.SetAsRequest(resourceID)
 		}
 
-		availableResources = ResolveChainedValues(availableResources, sw.state.Done())
-		availableResources = ResolveDependsOn(availableResources, sw.state.Done())
-		availableResources = ApplyEncoders(availableResources, sw.log)
+		availableResources = ResolveChainedValues(availableResources, e.state.Done())
+		availableResources = ResolveDependsOn(availableResources, e.state.Done())
+		availableResources = ApplyEncoders(availableResources, e.log)
 		availableResources = MultiplexStatements(availableResources)
 		availableResources = UnwrapNoMultiplex(availableResources)
 
 		for resourceID, stmt := range availableResources {
-			resourceID, stmt := resourceID, stmt
-			success := sw.goroutineLimiter.Acquire()
-			if !success {
-				select {
-				case sw.errorCh <- ErrMaxGoroutineDenied:
-				case <-sw.ctx.Done():
-				}
-
-				return
+			err := e.dispatch(resourceID, stmt)
+			if err != nil {
+				return nil, err
 			}
-
-			go func() {
-				select {
-				case sw.requestCh <- request{ResourceIdentifier: resourceID, Statement: stmt}:
-				case <-sw.ctx.Done():
-				}
-
-				sw.goroutineLimiter.Release()
-			}()
 		}
 
 		select {
-		case result := <-sw.resultCh:
-			sw.state.UpdateDone(result.ResourceIdentifier, result.Response)
-		case <-sw.ctx.Done():
-			return
+		case res := <-e.results:
+			e.state.UpdateDone(res.ResourceIdentifier, res.Response)
+			if e.shouldFailFast(res) {
+				return e.failFastResult(res.ResourceIdentifier), nil
+			}
+		case <-e.ctx.Done():
+			return nil, ErrQueryTimedOut
 		}
-	}
 
-	select {
-	case sw.outputCh <- sw.state.Done():
-	case <-sw.ctx.Done():
+		if failed, ok := e.collectReadyResults(); !ok {
+			return e.failFastResult(failed), nil
+		}
 	}
-}
 
-type requestWorker struct {
-	requestCh        chan request
-	resultCh         chan result
-	errorCh          chan error
-	executor         Executor
-	queryCtx         restql.QueryContext
-	ctx              context.Context
-	goroutineLimiter *limiter
+	return e.state.Done(), nil
 }
 
-func (rw *requestWorker) Run() {
+// collectReadyResults updates the state with all results already
+// available, so the next round dispatches every statement they unblock.
+// It stops on the first result that should fail the query fast.
+func (e *execution) collectReadyResults() (domain.ResourceID, bool) {
 	for {
 		select {
-		case req := <-rw.requestCh:
-			resourceID := req.ResourceIdentifier
-			statement := req.Statement
-
-			success := rw.goroutineLimiter.Acquire()
-			if !success {
-				select {
-				case rw.errorCh <- ErrMaxGoroutineDenied:
-				case <-rw.ctx.Done():
-				}
-
-				return
+		case res := <-e.results:
+			e.state.UpdateDone(res.ResourceIdentifier, res.Response)
+			if e.shouldFailFast(res) {
+				return res.ResourceIdentifier, false
 			}
-
-			switch statement := statement.(type) {
-			case domain.Statement:
-				go func() {
-					response := rw.executor.DoStatement(rw.ctx, statement, rw.queryCtx)
-					writeResult(rw.ctx, rw.resultCh, result{ResourceIdentifier: resourceID, Response: response})
-					rw.goroutineLimiter.Release()
-				}()
-			case []interface{}:
-				go func() {
-					result := rw.runMultiplexedStatement(statement, resourceID)
-					writeResult(rw.ctx, rw.resultCh, result)
-					rw.goroutineLimiter.Release()
-				}()
-			default:
-				// Should never reach this point
-				rw.goroutineLimiter.Release()
-			}
-		case <-rw.ctx.Done():
-			return
+		default:
+			return "", true
 		}
 	}
 }
 
-func (rw *requestWorker) runMultiplexedStatement(statements []interface{}, resourceID domain.ResourceID) result {
-	responseChans := make([]chan interface{}, len(statements))
-	for i := range responseChans {
-		responseChans[i] = make(chan interface{}, 1)
+func (e *execution) shouldFailFast(res result) bool {
+	return e.failFast && isRequiredFailure(res.Response)
+}
+
+// failFastResult cancels the statements in flight and returns the
+// done results along with a skipped result for each statement that
+// was pending or in flight when the failed statement finished.
+func (e *execution) failFastResult(failed domain.ResourceID) domain.Resources {
+	e.cancel()
+	e.log.Debug("query execution stopped due to failed statement", "resource", failed)
+
+	output := make(domain.Resources)
+	for resourceID, done := range e.state.Done() {
+		output[resourceID] = done
 	}
 
-	var wg sync.WaitGroup
+	for resourceID := range e.state.Requested() {
+		output[resourceID] = NewFailFastSkippedResponse(e.log, failed)
+	}
 
-	wg.Add(len(statements))
-	for i, stmt := range statements {
-		select {
-		case <-rw.ctx.Done():
-			return result{}
-		default:
-		}
+	for resourceID := range e.state.Todo() {
+		output[resourceID] = NewFailFastSkippedResponse(e.log, failed)
+	}
 
-		i, stmt := i, stmt
-		ch := responseChans[i]
+	return output
+}
 
-		success := rw.goroutineLimiter.Acquire()
-		if !success {
-			select {
-			case rw.errorCh <- ErrMaxGoroutineDenied:
-			case <-rw.ctx.Done():
+// isRequiredFailure returns true if the result, or any of
+// the multiplexed ones, failed without ignoring errors.
+func isRequiredFailure(response interface{}) bool {
+	switch r := response.(type) {
+	case restql.DoneResource:
+		return !r.IgnoreErrors && !r.Success && (r.Status < 200 || r.Status > 399)
+	case restql.DoneResources:
+		for _, dr := range r {
+			if isRequiredFailure(dr) {
+				return true
 			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func isFailFastEnabled(query domain.Query) bool {
+	enabled, ok := query.Use["fail-fast"].(bool)
+	return ok && enabled
+}
+
+func (e *execution) dispatch(resourceID domain.ResourceID, stmt interface{}) error {
+	switch stmt := stmt.(type) {
+	case domain.Statement:
+		return e.submit(task{execution: e, resourceID: resourceID, statement: stmt})
+	case []interface{}:
+		m := &multiplexedResult{
+			resourceID: resourceID,
+			responses:  make(restql.DoneResources, len(stmt)),
+			pending:    countStatements(stmt),
+			out:        e.results,
+		}
 
-			return result{}
+		if m.pending == 0 {
+			m.out <- result{ResourceIdentifier: resourceID, Response: m.responses}
+			return nil
 		}
 
+		return e.dispatchMultiplexed(m, stmt, m.responses)
+	default:
+		// Should never reach this point
+		return nil
+	}
+}
+
+// dispatchMultiplexed submits each multiplexed statement as a task of
+// its own, writing the response on its position of the result, instead
+// of having a goroutine waiting for the group to finish.
+func (e *execution) dispatchMultiplexed(m *multiplexedResult, statements []interface{}, responses restql.DoneResources) error {
+	for i, stmt := range statements {
 		switch stmt := stmt.(type) {
 		case domain.Statement:
-			go func() {
-				response := rw.executor.DoStatement(rw.ctx, stmt, rw.queryCtx)
-				ch <- response
-				wg.Done()
-				rw.goroutineLimiter.Release()
-			}()
+			err := e.submit(task{execution: e, statement: stmt, multiplexed: m, responses: responses, index: i})
+			if err != nil {
+				return err
+			}
 		case []interface{}:
-			go func() {
-				subResult := rw.runMultiplexedStatement(stmt, resourceID)
-				ch <- subResult.Response
-				wg.Done()
-				rw.goroutineLimiter.Release()
-			}()
-		}
-	}
+			subResponses := make(restql.DoneResources, len(stmt))
+			responses[i] = subResponses
 
-	wg.Wait()
-	responses := make(restql.DoneResources, len(statements))
-	for i, ch := range responseChans {
-		responses[i] = <-ch
+			err := e.dispatchMultiplexed(m, stmt, subResponses)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
-	return result{ResourceIdentifier: resourceID, Response: responses}
+	return nil
 }
 
-func writeResult(ctx context.Context, out chan result, r result) {
-	select {
-	case out <- r:
-	case <-ctx.Done():
+// submit runs the task on the worker pool once the goroutine quota is granted.
+func (e *execution) submit(t task) error {
+	err := e.goroutineQuota.Acquire()
+	if err != nil {
+		return err
 	}
-}
 
-type limiter struct {
-	mu sync.Mutex
+	ok := e.pool.Submit(e.ctx, t)
+	if !ok {
+		e.goroutineQuota.Release()
+		return ErrQueryTimedOut
+	}
 
-	limit  int32
-	bucket int32
+	return nil
 }
 
-func newLimiter(limit int32) *limiter {
-	return &limiter{limit: limit, bucket: limit}
-}
+// run executes the task statement, writing its response either as
+// the resource result or on its position of the multiplexed result.
+// Tasks of a query already finished are skipped.
+func (e *execution) run(t task) {
+	defer e.goroutineQuota.Release()
 
-// Acquire will try to reserve a token on limiter.
-// If the limiter bucket is full (default case on select), it will fail.
-func (l *limiter) Acquire() bool {
-	if l.limit <= 0 {
-		return true
+	if e.ctx.Err() != nil {
+		return
 	}
 
-	l.mu.Lock()
-	defer l.mu.Unlock()
+	response := e.executor.DoStatement(e.ctx, t.statement, e.queryCtx)
 
-	if l.bucket <= 0 {
-		l.bucket = 0
-		return false
+	if t.multiplexed == nil {
+		e.results <- result{ResourceIdentifier: t.resourceID, Response: response}
+		return
 	}
 
-	l.bucket = l.bucket - 1
-	return true
+	t.responses[t.index] = response
+	t.multiplexed.Done()
 }
 
-// Release will return a token to the bucket with a non-blocking read.
-func (l *limiter) Release() {
-	if l.limit <= 0 {
-		return
-	}
+// multiplexedResult gathers the responses of a multiplexed
+// statement, sending them as a single result when all are done.
+type multiplexedResult struct {
+	resourceID domain.ResourceID
+	responses  restql.DoneResources
+	pending    int32
+	out        chan result
+}
 
-	l.mu.Lock()
-	defer l.mu.Unlock()
+func (m *multiplexedResult) Done() {
+	if atomic.AddInt32(&m.pending, -1) == 0 {
+		m.out <- result{ResourceIdentifier: m.resourceID, Response: m.responses}
+	}
+}
 
-	if l.bucket >= l.limit {
-		l.bucket = l.limit
-		return
+func countStatements(statements []interface{}) int32 {
+	var count int32
+	for _, stmt := range statements {
+		switch stmt := stmt.(type) {
+		case domain.Statement:
+			count++
+		case []interface{}:
+			count += countStatements(stmt)
+		}
 	}
 
-	l.bucket = l.bucket + 1
+	return count
 }
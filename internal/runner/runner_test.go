@@ -0,0 +1,309 @@
+package runner_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/runner"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+type blockingClient struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (c blockingClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	if request.Host == "blocking.io" {
+		c.started <- struct{}{}
+		<-c.release
+	}
+
+	return restql.HTTPResponse{StatusCode: 200}, nil
+}
+
+func TestRunnerQuotas(t *testing.T) {
+	client := blockingClient{started: make(chan struct{}), release: make(chan struct{})}
+	executor := runner.NewExecutor(test.NoOpLogger, client, time.Second, "")
+	r := runner.NewRunner(test.NoOpLogger, executor, runner.Options{
+		GlobalQueryTimeout: time.Second,
+		TenantQuotas:       map[string]runner.Quota{"marketplace": {MaxConcurrentQueries: 1}},
+		NamespaceQuotas:    map[string]runner.Quota{"checkout": {MaxConcurrentQueries: 1}},
+	})
+
+	blocking, _ := restql.NewMapping("hero", "http://blocking.io/hero")
+	fast, _ := restql.NewMapping("hero", "http://fast.io/hero")
+	query := domain.Query{Statements: []domain.Statement{{Method: domain.FromMethod, Resource: "hero"}}}
+
+	queryCtx := func(tenant, namespace string, mapping restql.Mapping) restql.QueryContext {
+		return restql.QueryContext{
+			Mappings: map[string]restql.Mapping{"hero": mapping},
+			Options:  restql.QueryOptions{Tenant: tenant, Namespace: namespace},
+		}
+	}
+
+	ctx := restql.WithLogger(context.Background(), test.NoOpLogger)
+
+	done := make(chan error, 2)
+	go func() {
+		_, err := r.ExecuteQuery(ctx, query, queryCtx("marketplace", "", blocking))
+		done <- err
+	}()
+	<-client.started
+
+	go func() {
+		_, err := r.ExecuteQuery(ctx, query, queryCtx("storefront", "checkout", blocking))
+		done <- err
+	}()
+	<-client.started
+
+	_, err := r.ExecuteQuery(ctx, query, queryCtx("marketplace", "", fast))
+	test.Equal(t, errors.Is(err, runner.ErrTenantQueryQuotaDenied), true)
+
+	_, err = r.ExecuteQuery(ctx, query, queryCtx("storefront", "checkout", fast))
+	test.Equal(t, errors.Is(err, runner.ErrNamespaceQueryQuotaDenied), true)
+
+	_, err = r.ExecuteQuery(ctx, query, queryCtx("storefront", "", fast))
+	test.Equal(t, err, nil)
+
+	close(client.release)
+	test.Equal(t, <-done, nil)
+	test.Equal(t, <-done, nil)
+
+	_, err = r.ExecuteQuery(ctx, query, queryCtx("marketplace", "", fast))
+	test.Equal(t, err, nil)
+}
+
+func TestRunnerQueueing(t *testing.T) {
+	client := blockingClient{started: make(chan struct{}), release: make(chan struct{})}
+	executor := runner.NewExecutor(test.NoOpLogger, client, time.Second, "")
+	r := runner.NewRunner(test.NoOpLogger, executor, runner.Options{
+		GlobalQueryTimeout:   time.Second,
+		MaxConcurrentQueries: 1,
+		MaxQueueWait:         20 * time.Millisecond,
+	})
+
+	blocking, _ := restql.NewMapping("hero", "http://blocking.io/hero")
+	fast, _ := restql.NewMapping("hero", "http://fast.io/hero")
+	query := domain.Query{Statements: []domain.Statement{{Method: domain.FromMethod, Resource: "hero"}}}
+
+	ctx := restql.WithLogger(context.Background(), test.NoOpLogger)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := r.ExecuteQuery(ctx, query, restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": blocking}})
+		done <- err
+	}()
+	<-client.started
+
+	_, err := r.ExecuteQuery(ctx, query, restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": fast}})
+	test.Equal(t, errors.Is(err, runner.ErrMaxQueryDenied), true)
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		close(client.release)
+	}()
+
+	_, err = r.ExecuteQuery(ctx, query, restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": fast}})
+	test.Equal(t, err, nil)
+	test.Equal(t, <-done, nil)
+}
+
+func TestRunnerQueueingOnSaturatedTenant(t *testing.T) {
+	client := blockingClient{started: make(chan struct{}), release: make(chan struct{})}
+	executor := runner.NewExecutor(test.NoOpLogger, client, time.Second, "")
+	r := runner.NewRunner(test.NoOpLogger, executor, runner.Options{
+		GlobalQueryTimeout:   time.Second,
+		MaxConcurrentQueries: 2,
+		MaxQueueWait:         500 * time.Millisecond,
+		TenantQuotas:         map[string]runner.Quota{"marketplace": {MaxConcurrentQueries: 1}},
+	})
+
+	blocking, _ := restql.NewMapping("hero", "http://blocking.io/hero")
+	fast, _ := restql.NewMapping("hero", "http://fast.io/hero")
+	query := domain.Query{Statements: []domain.Statement{{Method: domain.FromMethod, Resource: "hero"}}}
+
+	queryCtx := func(tenant string, mapping restql.Mapping) restql.QueryContext {
+		return restql.QueryContext{
+			Mappings: map[string]restql.Mapping{"hero": mapping},
+			Options:  restql.QueryOptions{Tenant: tenant},
+		}
+	}
+
+	ctx := restql.WithLogger(context.Background(), test.NoOpLogger)
+
+	done := make(chan error, 2)
+	go func() {
+		_, err := r.ExecuteQuery(ctx, query, queryCtx("marketplace", blocking))
+		done <- err
+	}()
+	<-client.started
+
+	go func() {
+		_, err := r.ExecuteQuery(ctx, query, queryCtx("marketplace", fast))
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	start := time.Now()
+	_, err := r.ExecuteQuery(ctx, query, queryCtx("storefront", fast))
+	test.Equal(t, err, nil)
+	test.Equal(t, time.Since(start) < 250*time.Millisecond, true)
+
+	close(client.release)
+	test.Equal(t, <-done, nil)
+	test.Equal(t, <-done, nil)
+}
+
+type failingClient struct {
+	started   chan struct{}
+	cancelled chan struct{}
+}
+
+func (c failingClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	switch request.Host {
+	case "fail.io":
+		<-c.started
+		return restql.HTTPResponse{StatusCode: 503}, nil
+	case "slow.io":
+		close(c.started)
+		<-ctx.Done()
+		close(c.cancelled)
+		return restql.HTTPResponse{}, ctx.Err()
+	default:
+		return restql.HTTPResponse{StatusCode: 200}, nil
+	}
+}
+
+func TestRunnerFailFast(t *testing.T) {
+	client := failingClient{started: make(chan struct{}), cancelled: make(chan struct{})}
+	executor := runner.NewExecutor(test.NoOpLogger, client, time.Second, "")
+	r := runner.NewRunner(test.NoOpLogger, executor, runner.Options{GlobalQueryTimeout: time.Second})
+
+	hero, _ := restql.NewMapping("hero", "http://fail.io/hero")
+	sidekick, _ := restql.NewMapping("sidekick", "http://slow.io/sidekick")
+	villain, _ := restql.NewMapping("villain", "http://fast.io/villain")
+	queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": hero, "sidekick": sidekick, "villain": villain}}
+
+	query := domain.Query{
+		Use: domain.Modifiers{"fail-fast": true},
+		Statements: []domain.Statement{
+			{Method: domain.FromMethod, Resource: "hero"},
+			{Method: domain.FromMethod, Resource: "sidekick"},
+			{Method: domain.FromMethod, Resource: "villain", With: domain.Params{Values: map[string]interface{}{"id": domain.Chain{"hero", "id"}}}},
+		},
+	}
+
+	ctx := restql.WithLogger(context.Background(), test.NoOpLogger)
+
+	got, err := r.ExecuteQuery(ctx, query, queryCtx)
+	test.VerifyError(t, err)
+
+	test.Equal(t, got["hero"].(restql.DoneResource).Status, 503)
+	test.Equal(t, got["sidekick"].(restql.DoneResource).Skipped, true)
+	test.Equal(t, got["villain"].(restql.DoneResource).Skipped, true)
+	test.Equal(t, got["villain"].(restql.DoneResource).ResponseBody.Unmarshal(), "The request was skipped due to the failure of { hero }")
+
+	select {
+	case <-client.cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("in flight statement was not cancelled")
+	}
+}
+
+type statusEchoClient struct{}
+
+func (c statusEchoClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	status, _ := strconv.Atoi(fmt.Sprintf("%v", request.Query["status"]))
+	return restql.HTTPResponse{StatusCode: status}, nil
+}
+
+func TestRunnerMultiplexedStatements(t *testing.T) {
+	executor := runner.NewExecutor(test.NoOpLogger, statusEchoClient{}, time.Second, "")
+	r := runner.NewRunner(test.NoOpLogger, executor, runner.Options{GlobalQueryTimeout: time.Second, WorkerPoolSize: 2})
+
+	mapping, _ := restql.NewMapping("hero", "http://hero.io/hero")
+	queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping}}
+	query := domain.Query{Statements: []domain.Statement{
+		{
+			Method:   domain.FromMethod,
+			Resource: "hero",
+			With: domain.Params{Values: map[string]interface{}{
+				"status": []interface{}{200, []interface{}{201, 202}},
+			}},
+		},
+		{
+			Method:   domain.FromMethod,
+			Resource: "hero",
+			Alias:    "single",
+			With:     domain.Params{Values: map[string]interface{}{"status": 203}},
+		},
+	}}
+
+	ctx := restql.WithLogger(context.Background(), test.NoOpLogger)
+
+	got, err := r.ExecuteQuery(ctx, query, queryCtx)
+	test.VerifyError(t, err)
+
+	statuses := func(result interface{}) interface{} {
+		var collect func(r interface{}) interface{}
+		collect = func(r interface{}) interface{} {
+			switch r := r.(type) {
+			case restql.DoneResource:
+				return r.Status
+			case restql.DoneResources:
+				s := make([]interface{}, len(r))
+				for i, dr := range r {
+					s[i] = collect(dr)
+				}
+				return s
+			default:
+				return r
+			}
+		}
+		return collect(result)
+	}
+
+	test.Equal(t, statuses(got["hero"]), []interface{}{200, []interface{}{201, 202}})
+	test.Equal(t, statuses(got["single"]), 203)
+}
+
+type immediateClient struct{}
+
+func (c immediateClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	return restql.HTTPResponse{StatusCode: 200}, nil
+}
+
+func BenchmarkExecuteQuery(b *testing.B) {
+	mapping, _ := restql.NewMapping("hero", "http://hero.io/hero")
+	queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping}}
+
+	executor := runner.NewExecutor(test.NoOpLogger, immediateClient{}, time.Second, "")
+	r := runner.NewRunner(test.NoOpLogger, executor, runner.Options{GlobalQueryTimeout: 10 * time.Second})
+
+	ctx := restql.WithLogger(context.Background(), test.NoOpLogger)
+
+	for _, size := range []int{1, 10, 500} {
+		query := domain.Query{Statements: make([]domain.Statement, size)}
+		for i := range query.Statements {
+			query.Statements[i] = domain.Statement{Method: domain.FromMethod, Resource: "hero", Alias: fmt.Sprintf("hero%d", i)}
+		}
+
+		b.Run(fmt.Sprintf("%d statements", size), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				_, err := r.ExecuteQuery(ctx, query, queryCtx)
+				if err != nil {
+					b.Fatalf("failed to execute query : %v", err)
+				}
+			}
+		})
+	}
+}
@@ -127,6 +127,11 @@ func (s *State) SetAsRequest(resourceID domain.ResourceID) {
 	delete(s.todo, resourceID)
 }
 
+// Todo returns all Resources not yet requested
+func (s *State) Todo() domain.Resources {
+	return s.todo
+}
+
 // Requested returns all Resources being resolved
 func (s *State) Requested() domain.Resources {
 	return s.requested
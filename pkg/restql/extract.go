@@ -0,0 +1,316 @@
+package restql
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+var errMalformedJSON = errors.New("malformed json")
+
+// Extract returns the value found on the given path of the content of
+// ResponseBody. When the path reaches a list, the remaining path is
+// applied to each of its items. It returns false when the path is not
+// present or leads to a null value.
+//
+// If the content was not unmarshalled yet, only the values on the path
+// are parsed from the byte slice, which is kept untouched and can still
+// be sent to downstream as is.
+func (r *ResponseBody) Extract(path []string) (interface{}, bool) {
+	if r.jsonValue != nil || len(path) == 0 || !r.Valid() {
+		return extractFromValue(path, r.Unmarshal())
+	}
+
+	return extractFromBytes(path, r.jsonBytes)
+}
+
+// ExtractFields returns an object with only the given top level fields
+// of the content of ResponseBody. It returns false when the content is
+// not a JSON object.
+//
+// If the content was not unmarshalled yet, only the given fields
+// are parsed from the byte slice.
+func (r *ResponseBody) ExtractFields(fields []string) (map[string]interface{}, bool) {
+	if r.jsonValue != nil || !r.Valid() {
+		obj, ok := r.Unmarshal().(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		result := make(map[string]interface{}, len(fields))
+		for _, f := range fields {
+			if v, found := obj[f]; found {
+				result[f] = v
+			}
+		}
+		return result, true
+	}
+
+	b := r.jsonBytes
+	i := skipWhitespace(b, 0)
+	if b[i] != '{' {
+		return nil, false
+	}
+
+	result := make(map[string]interface{}, len(fields))
+	err := eachObjectField(b[i:], func(key []byte, value []byte) {
+		for _, f := range fields {
+			if !keyEquals(key, f) {
+				continue
+			}
+
+			v, err := unmarshalValue(value)
+			if err != nil {
+				r.log.Error("failed to unmarshal response body field", err, "field", f)
+				continue
+			}
+			result[f] = v
+		}
+	})
+	if err != nil {
+		r.log.Error("failed to extract response body fields", err)
+		return nil, false
+	}
+
+	return result, true
+}
+
+func extractFromValue(path []string, value interface{}) (interface{}, bool) {
+	if value == nil {
+		return nil, false
+	}
+
+	if len(path) == 0 {
+		return value, true
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		v, found := value[path[0]]
+		if !found {
+			return nil, false
+		}
+
+		return extractFromValue(path[1:], v)
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, v := range value {
+			result[i], _ = extractFromValue(path, v)
+		}
+		return result, true
+	default:
+		return nil, false
+	}
+}
+
+// extractFromBytes follows the path on a valid JSON document
+// skipping over the values outside of it without parsing them.
+func extractFromBytes(path []string, b []byte) (interface{}, bool) {
+	i := skipWhitespace(b, 0)
+	if i >= len(b) || bytes.HasPrefix(b[i:], []byte("null")) {
+		return nil, false
+	}
+
+	if len(path) == 0 {
+		v, err := unmarshalValue(b[i:])
+		if err != nil {
+			return nil, false
+		}
+		return v, v != nil
+	}
+
+	switch b[i] {
+	case '{':
+		var found []byte
+		err := eachObjectField(b[i:], func(key []byte, value []byte) {
+			// the last occurrence of a duplicated key wins, as when unmarshalling
+			if keyEquals(key, path[0]) {
+				found = value
+			}
+		})
+		if err != nil || found == nil {
+			return nil, false
+		}
+
+		return extractFromBytes(path[1:], found)
+	case '[':
+		result := make([]interface{}, 0)
+		err := eachArrayItem(b[i:], func(item []byte) {
+			v, _ := extractFromBytes(path, item)
+			result = append(result, v)
+		})
+		if err != nil {
+			return nil, false
+		}
+
+		return result, true
+	default:
+		return nil, false
+	}
+}
+
+func unmarshalValue(b []byte) (interface{}, error) {
+	var v interface{}
+	err := json.Unmarshal(b, &v)
+	return v, err
+}
+
+func keyEquals(key []byte, name string) bool {
+	if bytes.IndexByte(key, '\\') < 0 {
+		return string(key[1:len(key)-1]) == name
+	}
+
+	var k string
+	if err := json.Unmarshal(key, &k); err != nil {
+		return false
+	}
+	return k == name
+}
+
+// eachObjectField calls fn with the raw key, quotes included,
+// and the raw value of each field of the object starting on b.
+func eachObjectField(b []byte, fn func(key []byte, value []byte)) error {
+	i := skipWhitespace(b, 1)
+	if i < len(b) && b[i] == '}' {
+		return nil
+	}
+
+	for i < len(b) {
+		keyEnd, err := skipValue(b, i)
+		if err != nil {
+			return err
+		}
+		key := b[i:keyEnd]
+
+		i = skipWhitespace(b, keyEnd)
+		if i >= len(b) || b[i] != ':' {
+			return errMalformedJSON
+		}
+
+		valueStart := skipWhitespace(b, i+1)
+		valueEnd, err := skipValue(b, valueStart)
+		if err != nil {
+			return err
+		}
+
+		fn(key, b[valueStart:valueEnd])
+
+		i = skipWhitespace(b, valueEnd)
+		if i >= len(b) {
+			return errMalformedJSON
+		}
+
+		switch b[i] {
+		case ',':
+			i = skipWhitespace(b, i+1)
+		case '}':
+			return nil
+		default:
+			return errMalformedJSON
+		}
+	}
+
+	return errMalformedJSON
+}
+
+// eachArrayItem calls fn with the raw value of each
+// item of the array starting on b.
+func eachArrayItem(b []byte, fn func(item []byte)) error {
+	i := skipWhitespace(b, 1)
+	if i < len(b) && b[i] == ']' {
+		return nil
+	}
+
+	for i < len(b) {
+		end, err := skipValue(b, i)
+		if err != nil {
+			return err
+		}
+
+		fn(b[i:end])
+
+		i = skipWhitespace(b, end)
+		if i >= len(b) {
+			return errMalformedJSON
+		}
+
+		switch b[i] {
+		case ',':
+			i = skipWhitespace(b, i+1)
+		case ']':
+			return nil
+		default:
+			return errMalformedJSON
+		}
+	}
+
+	return errMalformedJSON
+}
+
+// skipValue returns the position right after the JSON value starting on i.
+func skipValue(b []byte, i int) (int, error) {
+	if i >= len(b) {
+		return 0, errMalformedJSON
+	}
+
+	switch b[i] {
+	case '"':
+		return skipString(b, i)
+	case '{', '[':
+		depth := 0
+		for j := i; j < len(b); j++ {
+			switch b[j] {
+			case '"':
+				end, err := skipString(b, j)
+				if err != nil {
+					return 0, err
+				}
+				j = end - 1
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return j + 1, nil
+				}
+			}
+		}
+		return 0, errMalformedJSON
+	default:
+		j := i
+		for j < len(b) {
+			switch b[j] {
+			case ',', '}', ']', ' ', '\t', '\n', '\r':
+				return j, nil
+			}
+			j++
+		}
+		return j, nil
+	}
+}
+
+func skipString(b []byte, i int) (int, error) {
+	for j := i + 1; j < len(b); j++ {
+		switch b[j] {
+		case '\\':
+			j++
+		case '"':
+			return j + 1, nil
+		}
+	}
+
+	return 0, errMalformedJSON
+}
+
+func skipWhitespace(b []byte, i int) int {
+	for i < len(b) {
+		switch b[i] {
+		case ' ', '\t', '\n', '\r':
+			i++
+		default:
+			return i
+		}
+	}
+
+	return i
+}
@@ -0,0 +1,88 @@
+package restql_test
+
+import (
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestResponseBodyExtract(t *testing.T) {
+	body := `{
+		"id": 1,
+		"name": "batman",
+		"escaped\"key": "yes",
+		"nothing": null,
+		"partner": {"name": "robin", "tags": ["a", "b"]},
+		"villains": [{"name": "joker", "crimes": [{"id": 1}]}, {"name": "bane"}, null],
+		"weird": "}{][,\"",
+		"name": "bruce"
+	}`
+
+	tests := []struct {
+		name          string
+		path          []string
+		expected      interface{}
+		expectedFound bool
+	}{
+		{"should extract the whole body on empty path", []string{}, test.Unmarshal(body), true},
+		{"should extract top level field", []string{"id"}, float64(1), true},
+		{"should extract last duplicated field", []string{"name"}, "bruce", true},
+		{"should extract field with escaped key", []string{"escaped\"key"}, "yes", true},
+		{"should extract nested field", []string{"partner", "name"}, "robin", true},
+		{"should extract nested list", []string{"partner", "tags"}, []interface{}{"a", "b"}, true},
+		{"should extract field from list items", []string{"villains", "name"}, []interface{}{"joker", "bane", nil}, true},
+		{"should extract field from nested lists", []string{"villains", "crimes", "id"}, []interface{}{[]interface{}{float64(1)}, nil, nil}, true},
+		{"should extract string with json delimiters", []string{"weird"}, "}{][,\"", true},
+		{"should not find null field", []string{"nothing"}, nil, false},
+		{"should not find unknown field", []string{"unknown"}, nil, false},
+		{"should not find field under primitive", []string{"id", "value"}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fromBytes := restql.NewResponseBodyFromBytes(test.NoOpLogger, []byte(body))
+			got, found := fromBytes.Extract(tt.path)
+			test.Equal(t, found, tt.expectedFound)
+			test.Equal(t, got, tt.expected)
+			if len(tt.path) > 0 {
+				test.Equal(t, fromBytes.Value(), nil)
+			}
+
+			fromValue := restql.NewResponseBodyFromValue(test.NoOpLogger, test.Unmarshal(body))
+			got, found = fromValue.Extract(tt.path)
+			test.Equal(t, found, tt.expectedFound)
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
+func TestResponseBodyExtractFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		fields     []string
+		expected   map[string]interface{}
+		expectedOk bool
+	}{
+		{
+			"should extract only selected fields",
+			`{"id": 1, "name": "batman", "nothing": null, "partner": {"name": "robin"}}`,
+			[]string{"id", "nothing", "partner", "unknown"},
+			map[string]interface{}{"id": float64(1), "nothing": nil, "partner": map[string]interface{}{"name": "robin"}},
+			true,
+		},
+		{"should extract from empty object", `{ }`, []string{"id"}, map[string]interface{}{}, true},
+		{"should not extract from list", `[{"id": 1}]`, []string{"id"}, nil, false},
+		{"should not extract from invalid json", `{"id": `, []string{"id"}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rb := restql.NewResponseBodyFromBytes(test.NoOpLogger, []byte(tt.body))
+			got, ok := rb.ExtractFields(tt.fields)
+			test.Equal(t, ok, tt.expectedOk)
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
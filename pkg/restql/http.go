@@ -31,5 +31,9 @@ type HTTPResponse struct {
 	Body       *ResponseBody
 	Headers    Headers
 	Duration   time.Duration
-}
 
+	// Size is the length of the response body, while CompressedSize
+	// is the length received from the upstream when it was compressed.
+	Size           int
+	CompressedSize int
+}
@@ -1,15 +1,18 @@
 package restql
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 )
 
 var pathParamRegex = regexp.MustCompile(":([^/]+)/?")
 var urlRegex = regexp.MustCompile(`(https?)://([^/]+)([^?]*)\??(.*)`)
+var schemaRegex = regexp.MustCompile(`^\s*[a-zA-Z][a-zA-Z0-9+.-]*://`)
 
 // Mapping represents the association of a name to a REST resource url.
 // It support special syntax in the URL to provide dynamic value substitution, like:
@@ -19,43 +22,213 @@ var urlRegex = regexp.MustCompile(`(https?)://([^/]+)([^?]*)\??(.*)`)
 //• QueryRevisions parameters: can be defined by placing a colon (:) before an identifier in the URL query,
 // for example "http://some.api?:page", will replace ":page" by the value of the "page" parameter
 // in the query definition creating the URL "http://some.api?page=<value>".
+//• Multiple endpoints: can be defined by separating the URLs with a comma (,),
+// for example "http://some.api/:id, http://other.api/:id". All URLs must have the
+// same path and query, differing only by schema and host.
+//• Extended definition: a JSON object following the MappingDefinition form can be used
+// in place of the URL, associating defaults to the requests sent to the resource.
 type Mapping struct {
 	resourceName  string
 	url           string
 	schema        string
 	host          string
+	endpoints     []Endpoint
 	path          string
 	query         map[string]interface{}
 	pathParams    []string
 	pathParamsSet map[string]struct{}
+	definition    MappingDefinition
+	timeout       time.Duration
 
 	Source Source
 }
 
+// MappingDefinition is the extended form of a mapping. Besides
+// the resource URL, it defines the defaults of the requests sent
+// to the resource, which are overridden by the statement.
+//• Headers: sent on every request, unless the statement defines them.
+//• Timeout: a duration string used when the statement does not define a timeout.
+//• Methods: the statement methods allowed on the resource, like "from" or "to". All are allowed if empty.
+//• ContentType: used when the statement does not define the Content-Type header.
+//• Retry: how many times a failed request to the resource is retried.
+//• Cache: the cache directives used when the statement does not define them.
+//• Overrides: how the statements of a method, like "from", are sent to the resource.
+type MappingDefinition struct {
+	URL         string            `json:"url" yaml:"url"`
+	Description string            `json:"description,omitempty" yaml:"description"`
+	Headers     map[string]string `json:"headers,omitempty" yaml:"headers"`
+	Timeout     string            `json:"timeout,omitempty" yaml:"timeout"`
+	Methods     []string          `json:"methods,omitempty" yaml:"methods"`
+	ContentType string            `json:"contentType,omitempty" yaml:"contentType"`
+	Retry       *RetryPolicy      `json:"retry,omitempty" yaml:"retry"`
+	Cache       *CachePolicy      `json:"cache,omitempty" yaml:"cache"`
+	Response    *ResponsePolicy   `json:"response,omitempty" yaml:"response"`
+	Compression *bool             `json:"compression,omitempty" yaml:"compression"`
+
+	Overrides map[string]MethodOverride `json:"overrides,omitempty" yaml:"overrides"`
+}
+
+// MethodOverride changes the HTTP method used by the statements
+// of a method and, if Body is true, sends the statement
+// parameters as the JSON body instead of query parameters.
+// For example, a "from" statement can be sent as POST to a
+// resource that exposes reads as a search endpoint.
+type MethodOverride struct {
+	Method string `json:"method,omitempty" yaml:"method"`
+	Body   bool   `json:"body,omitempty" yaml:"body"`
+}
+
+// RetryPolicy defines how many times a failed request is sent again,
+// waiting the Backoff duration string between them. Only requests
+// that failed to complete or returned a 5xx status code are retried.
+type RetryPolicy struct {
+	Attempts int    `json:"attempts" yaml:"attempts"`
+	Backoff  string `json:"backoff,omitempty" yaml:"backoff"`
+}
+
+// CachePolicy defines the cache directives of the responses
+// of a resource, with the times in seconds.
+type CachePolicy struct {
+	MaxAge               *int `json:"maxAge,omitempty" yaml:"maxAge"`
+	SMaxAge              *int `json:"sMaxAge,omitempty" yaml:"sMaxAge"`
+	StaleWhileRevalidate *int `json:"staleWhileRevalidate,omitempty" yaml:"staleWhileRevalidate"`
+	StaleIfError         *int `json:"staleIfError,omitempty" yaml:"staleIfError"`
+
+	NoCache        bool `json:"noCache,omitempty" yaml:"noCache"`
+	NoStore        bool `json:"noStore,omitempty" yaml:"noStore"`
+	Private        bool `json:"private,omitempty" yaml:"private"`
+	MustRevalidate bool `json:"mustRevalidate,omitempty" yaml:"mustRevalidate"`
+}
+
+// Formats used to decode the body of the upstream responses.
+const (
+	JSONFormat   = "json"
+	XMLFormat    = "xml"
+	TextFormat   = "text"
+	FormFormat   = "form"
+	CSVFormat    = "csv"
+	BinaryFormat = "binary"
+)
+
+// ResponsePolicy defines how the body of the responses of a resource
+// is decoded. When the Format is empty it is chosen by the response
+// Content-Type. XML attributes are stored with the AttributePrefix,
+// "@" by default, and the text of elements with attributes or
+// children is stored on the TextKey, "#text" by default. CSV rows
+// are decoded as objects keyed by the header row, or as lists of
+// values if NoHeader is true.
+type ResponsePolicy struct {
+	Format          string `json:"format,omitempty" yaml:"format"`
+	AttributePrefix string `json:"attributePrefix,omitempty" yaml:"attributePrefix"`
+	TextKey         string `json:"textKey,omitempty" yaml:"textKey"`
+	NoHeader        bool   `json:"noHeader,omitempty" yaml:"noHeader"`
+}
+
+// Encode returns the value used to store the definition,
+// which is the plain URL if it does not define any default
+// or a JSON object otherwise.
+func (md MappingDefinition) Encode() (string, error) {
+	if md.isPlainURL() {
+		return md.URL, nil
+	}
+
+	b, err := json.Marshal(md)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+func (md MappingDefinition) isPlainURL() bool {
+	return md.Description == "" && len(md.Headers) == 0 && md.Timeout == "" && len(md.Methods) == 0 &&
+		md.ContentType == "" && md.Retry == nil && md.Cache == nil && md.Response == nil &&
+		md.Compression == nil && len(md.Overrides) == 0
+}
+
+// ParseMappingDefinition reads the value of a mapping,
+// either a plain URL or a JSON object.
+func ParseMappingDefinition(value string) (MappingDefinition, error) {
+	trimmed := strings.TrimSpace(value)
+	if !strings.HasPrefix(trimmed, "{") {
+		return MappingDefinition{URL: value}, nil
+	}
+
+	var md MappingDefinition
+	if err := json.Unmarshal([]byte(trimmed), &md); err != nil {
+		return MappingDefinition{}, errors.Wrapf(err, "failed to parse mapping definition %s", value)
+	}
+
+	return md, nil
+}
+
+// Endpoint represents one of the locations,
+// defined by schema and host, serving a resource.
+type Endpoint struct {
+	Schema string
+	Host   string
+}
+
 // NewMapping constructs a Mapping value from a resource name
 // and a canonical URL with optional identifiers for
-// path and query parameters.
-func NewMapping(resource, url string) (Mapping, error) {
-	mapping := Mapping{resourceName: resource, url: url}
+// path and query parameters, or an extended definition
+// in the JSON form.
+func NewMapping(resource, value string) (Mapping, error) {
+	definition, err := ParseMappingDefinition(value)
+	if err != nil {
+		return Mapping{}, err
+	}
 
-	urlMatches := urlRegex.FindAllStringSubmatch(url, -1)
-	if len(urlMatches) == 0 {
-		return Mapping{}, errors.Errorf("failed to create mapping from %s", url)
+	return NewMappingFromDefinition(resource, definition)
+}
+
+// NewMappingFromDefinition constructs a Mapping value
+// from a resource name and an extended definition.
+func NewMappingFromDefinition(resource string, definition MappingDefinition) (Mapping, error) {
+	url := definition.URL
+	mapping := Mapping{resourceName: resource, url: url, definition: definition}
+
+	if definition.Timeout != "" {
+		timeout, err := time.ParseDuration(definition.Timeout)
+		if err != nil {
+			return Mapping{}, errors.Wrapf(err, "failed to create mapping from %s : invalid timeout", url)
+		}
+		mapping.timeout = timeout
+	}
+
+	if definition.Retry != nil && definition.Retry.Backoff != "" {
+		if _, err := time.ParseDuration(definition.Retry.Backoff); err != nil {
+			return Mapping{}, errors.Wrapf(err, "failed to create mapping from %s : invalid retry backoff", url)
+		}
 	}
 
-	m := urlMatches[0]
-	if len(m) < 3 {
-		return Mapping{}, errors.Errorf("failed to create mapping from %s", url)
+	if definition.Response != nil && !isResponseFormat(definition.Response.Format) {
+		return Mapping{}, errors.Errorf("failed to create mapping from %s : invalid response format %s", url, definition.Response.Format)
+	}
+
+	urls := splitEndpoints(url)
+
+	m, err := matchURL(strings.TrimSpace(urls[0]))
+	if err != nil {
+		return Mapping{}, err
 	}
 	mapping.schema = m[1]
 	mapping.host = m[2]
+	mapping.path = m[3]
+	mapping.query = parseQueryParametersInURL(m[4])
+	mapping.endpoints = []Endpoint{{Schema: m[1], Host: m[2]}}
+
+	for _, u := range urls[1:] {
+		em, err := matchURL(strings.TrimSpace(u))
+		if err != nil {
+			return Mapping{}, err
+		}
 
-	if len(m) >= 4 {
-		mapping.path = m[3]
-	}
+		if em[3] != m[3] || em[4] != m[4] {
+			return Mapping{}, errors.Errorf("failed to create mapping from %s : endpoints must have the same path and query", url)
+		}
 
-	if len(m) >= 5 {
-		mapping.query = parseQueryParametersInURL(m[4])
+		mapping.endpoints = append(mapping.endpoints, Endpoint{Schema: em[1], Host: em[2]})
 	}
 
 	paramsMatches := pathParamRegex.FindAllStringSubmatch(mapping.path, -1)
@@ -74,6 +247,36 @@ func NewMapping(resource, url string) (Mapping, error) {
 	return mapping, nil
 }
 
+// splitEndpoints breaks a comma separated list of URLs into its
+// items. A comma only starts a new URL when it is followed by a
+// schema, hence commas on the path or query of an URL are kept.
+func splitEndpoints(url string) []string {
+	var urls []string
+
+	parts := strings.Split(url, ",")
+	current := parts[0]
+	for _, part := range parts[1:] {
+		if !schemaRegex.MatchString(part) {
+			current += "," + part
+			continue
+		}
+
+		urls = append(urls, current)
+		current = part
+	}
+
+	return append(urls, current)
+}
+
+func matchURL(url string) ([]string, error) {
+	urlMatches := urlRegex.FindAllStringSubmatch(url, -1)
+	if len(urlMatches) == 0 || len(urlMatches[0]) < 5 {
+		return nil, errors.Errorf("failed to create mapping from %s", url)
+	}
+
+	return urlMatches[0], nil
+}
+
 func parseQueryParametersInURL(queryParams string) map[string]interface{} {
 	if queryParams == "" {
 		return nil
@@ -97,6 +300,113 @@ func (m Mapping) URL() string {
 	return m.url
 }
 
+// Definition returns the extended form of the mapping
+func (m Mapping) Definition() MappingDefinition {
+	return m.definition
+}
+
+// Description returns the resource description
+func (m Mapping) Description() string {
+	return m.definition.Description
+}
+
+// Headers returns the headers sent by default to the resource
+func (m Mapping) Headers() map[string]string {
+	return m.definition.Headers
+}
+
+// Timeout returns the default timeout of requests to the resource,
+// or zero if not defined
+func (m Mapping) Timeout() time.Duration {
+	return m.timeout
+}
+
+// ContentType returns the default content type of requests to the resource
+func (m Mapping) ContentType() string {
+	return m.definition.ContentType
+}
+
+// AllowsMethod returns true if the statement method can be used on the resource
+func (m Mapping) AllowsMethod(method string) bool {
+	if len(m.definition.Methods) == 0 {
+		return true
+	}
+
+	for _, allowed := range m.definition.Methods {
+		if strings.EqualFold(allowed, method) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Retry returns how many times a failed request to the
+// resource is retried and the time waited between them
+func (m Mapping) Retry() (attempts int, backoff time.Duration) {
+	if m.definition.Retry == nil {
+		return 0, 0
+	}
+
+	backoff, _ = time.ParseDuration(m.definition.Retry.Backoff)
+	return m.definition.Retry.Attempts, backoff
+}
+
+// MethodOverride returns how the statements of the given method are
+// sent to the resource, if the mapping changes it
+func (m Mapping) MethodOverride(method string) (MethodOverride, bool) {
+	override, found := m.definition.Overrides[method]
+	return override, found
+}
+
+// Cache returns the default cache directives of the resource responses
+func (m Mapping) Cache() CachePolicy {
+	if m.definition.Cache == nil {
+		return CachePolicy{}
+	}
+
+	return *m.definition.Cache
+}
+
+// Compression tells if the requests to the resource ask for
+// compressed responses, using the given default when the
+// mapping does not define it.
+func (m Mapping) Compression(enabled bool) bool {
+	if m.definition.Compression == nil {
+		return enabled
+	}
+
+	return *m.definition.Compression
+}
+
+// Response returns how the body of the resource responses is decoded,
+// with the default XML keys filled.
+func (m Mapping) Response() ResponsePolicy {
+	var policy ResponsePolicy
+	if m.definition.Response != nil {
+		policy = *m.definition.Response
+	}
+
+	if policy.AttributePrefix == "" {
+		policy.AttributePrefix = "@"
+	}
+
+	if policy.TextKey == "" {
+		policy.TextKey = "#text"
+	}
+
+	return policy
+}
+
+func isResponseFormat(format string) bool {
+	switch format {
+	case "", JSONFormat, XMLFormat, TextFormat, FormFormat, CSVFormat, BinaryFormat:
+		return true
+	default:
+		return false
+	}
+}
+
 // ResourceName return the name associated with the resource URL
 func (m Mapping) ResourceName() string {
 	return m.resourceName
@@ -118,6 +428,12 @@ func (m Mapping) Host() string {
 	return m.host
 }
 
+// Endpoints returns the schema and host of all
+// locations serving the resource, in the order defined.
+func (m Mapping) Endpoints() []Endpoint {
+	return m.endpoints
+}
+
 // IsQueryParam returns true if the given name is a query parameter identifier
 func (m Mapping) IsQueryParam(name string) bool {
 	_, found := m.query[name]
@@ -3,6 +3,7 @@ package restql_test
 import (
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"testing"
+	"time"
 
 	"github.com/b2wdigital/restQL-golang/v6/test"
 )
@@ -38,6 +39,18 @@ func TestMappingsPathWithParams(t *testing.T) {
 			map[string]interface{}{"id": "12345", "name": "batman"},
 			"/hero/12345/info/batman",
 		},
+		{
+			"should keep commas on the path",
+			"http://hero.api/items/a,b/:id",
+			map[string]interface{}{"id": "12345"},
+			"/items/a,b/12345",
+		},
+		{
+			"should replace path param with multiple endpoints",
+			"http://hero-1.api/hero/:id, https://hero-2.api/hero/:id",
+			map[string]interface{}{"id": "12345"},
+			"/hero/12345",
+		},
 	}
 
 	for _, tt := range tests {
@@ -76,6 +89,12 @@ func TestMappingsQueryWithParams(t *testing.T) {
 			map[string]interface{}{"id": "12345", "foo": "bar", "name": "Batman"},
 			map[string]interface{}{"id": "12345", "name": "Batman"},
 		},
+		{
+			"should replace query parameters with multiple endpoints",
+			"http://hero-1.api/hero?:id,http://hero-2.api/hero?:id",
+			map[string]interface{}{"id": "12345", "foo": "bar"},
+			map[string]interface{}{"id": "12345"},
+		},
 		{
 			"should remove query parameter if value is not provided",
 			"http://hero.api/hero?:id&:name",
@@ -94,3 +113,225 @@ func TestMappingsQueryWithParams(t *testing.T) {
 		})
 	}
 }
+
+func TestMappingsEndpoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		expected []restql.Endpoint
+	}{
+		{
+			"should return single endpoint",
+			"http://hero.api/hero/:id",
+			[]restql.Endpoint{{Schema: "http", Host: "hero.api"}},
+		},
+		{
+			"should return multiple endpoints in order",
+			"http://hero-1.api/hero/:id, https://hero-2.api:8080/hero/:id",
+			[]restql.Endpoint{{Schema: "http", Host: "hero-1.api"}, {Schema: "https", Host: "hero-2.api:8080"}},
+		},
+		{
+			"should keep commas on the query string",
+			"http://hero.api/hero?fields=name,age",
+			[]restql.Endpoint{{Schema: "http", Host: "hero.api"}},
+		},
+		{
+			"should keep commas on the path",
+			"http://hero.api/items/a,b/:id",
+			[]restql.Endpoint{{Schema: "http", Host: "hero.api"}},
+		},
+		{
+			"should return multiple endpoints with commas on the path",
+			"http://hero-1.api/items/a,b/:id, http://hero-2.api/items/a,b/:id",
+			[]restql.Endpoint{{Schema: "http", Host: "hero-1.api"}, {Schema: "http", Host: "hero-2.api"}},
+		},
+		{
+			"should return multiple endpoints with commas on the query string",
+			"http://hero-1.api/hero?fields=name,age,http://hero-2.api/hero?fields=name,age",
+			[]restql.Endpoint{{Schema: "http", Host: "hero-1.api"}, {Schema: "http", Host: "hero-2.api"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping, err := restql.NewMapping("test-resource", tt.url)
+			test.VerifyError(t, err)
+
+			test.Equal(t, mapping.Endpoints(), tt.expected)
+			test.Equal(t, mapping.Host(), tt.expected[0].Host)
+		})
+	}
+}
+
+func TestMappingsWithInvalidEndpoints(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{"should fail with invalid url", "hero.api/hero"},
+		{"should fail with invalid secondary url", "http://hero-1.api/hero, ftp://hero-2.api/hero"},
+		{"should fail with endpoints with different paths", "http://hero-1.api/hero/:id, http://hero-2.api/heroes/:id"},
+		{"should fail with endpoints with different queries", "http://hero-1.api/hero?:id, http://hero-2.api/hero?:name"},
+		{"should fail with invalid definition", `{"url": "http://hero.api/hero"`},
+		{"should fail with definition without url", `{"timeout": "300ms"}`},
+		{"should fail with invalid timeout", `{"url": "http://hero.api/hero", "timeout": "soon"}`},
+		{"should fail with invalid response format", `{"url": "http://hero.api/hero", "response": {"format": "yaml"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := restql.NewMapping("test-resource", tt.url)
+			if err == nil {
+				t.Errorf("expected error for mapping %s", tt.url)
+			}
+		})
+	}
+}
+
+func TestMappingsWithDefinition(t *testing.T) {
+	type defaults struct {
+		URL         string
+		Host        string
+		Description string
+		Headers     map[string]string
+		Timeout     time.Duration
+		ContentType string
+		AllowsFrom  bool
+		AllowsTo    bool
+		Retries     int
+		Backoff     time.Duration
+		MaxAge      *int
+		Private     bool
+	}
+
+	maxAge := 60
+
+	tests := []struct {
+		name     string
+		value    string
+		expected defaults
+	}{
+		{
+			"should keep plain url form",
+			"http://hero.api/hero/:id",
+			defaults{URL: "http://hero.api/hero/:id", Host: "hero.api", AllowsFrom: true, AllowsTo: true},
+		},
+		{
+			"should read extended definition",
+			`{
+				"url": "http://hero.api/hero/:id",
+				"description": "heroes of the universe",
+				"headers": {"X-Api-Key": "abc"},
+				"timeout": "300ms",
+				"methods": ["from"],
+				"contentType": "application/vnd.hero+json",
+				"retry": {"attempts": 2, "backoff": "10ms"},
+				"cache": {"maxAge": 60, "private": true}
+			}`,
+			defaults{
+				URL:         "http://hero.api/hero/:id",
+				Host:        "hero.api",
+				Description: "heroes of the universe",
+				Headers:     map[string]string{"X-Api-Key": "abc"},
+				Timeout:     300 * time.Millisecond,
+				ContentType: "application/vnd.hero+json",
+				AllowsFrom:  true,
+				Retries:     2,
+				Backoff:     10 * time.Millisecond,
+				MaxAge:      &maxAge,
+				Private:     true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping, err := restql.NewMapping("hero", tt.value)
+			test.VerifyError(t, err)
+
+			retries, backoff := mapping.Retry()
+			got := defaults{
+				URL:         mapping.URL(),
+				Host:        mapping.Host(),
+				Description: mapping.Description(),
+				Headers:     mapping.Headers(),
+				Timeout:     mapping.Timeout(),
+				ContentType: mapping.ContentType(),
+				AllowsFrom:  mapping.AllowsMethod("from"),
+				AllowsTo:    mapping.AllowsMethod("to"),
+				Retries:     retries,
+				Backoff:     backoff,
+				MaxAge:      mapping.Cache().MaxAge,
+				Private:     mapping.Cache().Private,
+			}
+
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
+func TestMappingDefinitionEncode(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition restql.MappingDefinition
+		expected   string
+	}{
+		{
+			"should encode plain url",
+			restql.MappingDefinition{URL: "http://hero.api/hero"},
+			"http://hero.api/hero",
+		},
+		{
+			"should encode extended definition as json",
+			restql.MappingDefinition{URL: "http://hero.api/hero", Timeout: "300ms"},
+			`{"url":"http://hero.api/hero","timeout":"300ms"}`,
+		},
+		{
+			"should encode definition with response policy as json",
+			restql.MappingDefinition{URL: "http://hero.api/hero", Response: &restql.ResponsePolicy{Format: "xml"}},
+			`{"url":"http://hero.api/hero","response":{"format":"xml"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.definition.Encode()
+			test.VerifyError(t, err)
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
+func TestMappingDefinitionEncodeRoundTrip(t *testing.T) {
+	compression := false
+
+	tests := []struct {
+		name       string
+		definition restql.MappingDefinition
+	}{
+		{
+			"should keep plain url",
+			restql.MappingDefinition{URL: "http://hero.api/hero"},
+		},
+		{
+			"should keep response policy",
+			restql.MappingDefinition{URL: "http://hero.api/hero", Response: &restql.ResponsePolicy{Format: "csv", NoHeader: true}},
+		},
+		{
+			"should keep compression",
+			restql.MappingDefinition{URL: "http://hero.api/hero", Compression: &compression},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := tt.definition.Encode()
+			test.VerifyError(t, err)
+
+			mapping, err := restql.NewMapping("hero", encoded)
+			test.VerifyError(t, err)
+
+			test.Equal(t, mapping.Definition(), tt.definition)
+		})
+	}
+}
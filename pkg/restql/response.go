@@ -12,13 +12,15 @@ import (
 // from the HTTP response and returning it to downstream.
 //
 // When features need to access the content of the JSON response, they can
-// use the Unmarshal method to get it.
+// use the Unmarshal method to get it. If only part of the content is needed,
+// the Extract and ExtractFields methods read it without unmarshalling the
+// remaining, keeping the byte slice to be returned to downstream.
 //
 // If the byte slice is unmarshalled or a new value is set on the response body
 // with SetValue method, then the Marshal and Unmarshal function will operate
 // using this value rather then the byte slice.
 type ResponseBody struct {
-	log Logger
+	log       Logger
 	jsonBytes []byte
 	jsonValue interface{}
 }
@@ -60,12 +62,12 @@ func (r *ResponseBody) SetValue(v interface{}) {
 // be sent to downstream.
 //
 // This method can process the content in 4 ways:
-// - If there is a generic data, marshal it using a JSON parser
-//   and return the result as a json.RawMessage.
-// - Else, if the byte slice is empty, return nil.
-// - Else, if the byte slice is not an valid JSON, stringify it.
-// - Finally, if the byte slice is not empty and is a valid json,
-//   return it as a json.RawMessage.
+//   - If there is a generic data, marshal it using a JSON parser
+//     and return the result as a json.RawMessage.
+//   - Else, if the byte slice is empty, return nil.
+//   - Else, if the byte slice is not an valid JSON, stringify it.
+//   - Finally, if the byte slice is not empty and is a valid json,
+//     return it as a json.RawMessage.
 func (r *ResponseBody) Marshal() (interface{}, error) {
 	if r.jsonValue != nil {
 		b, err := json.Marshal(r.jsonValue)
@@ -91,11 +93,11 @@ func (r *ResponseBody) Marshal() (interface{}, error) {
 // be manipulated internally by restQL.
 //
 // This method can process the content in 4 ways:
-// - If there is a generic data, return it.
-// - Else, if the byte slice is empty or is not a valid json,
-//   return it as a string.
-// - Finally, if it is valid to be manipulated, then unmarshal it
-//   and return.
+//   - If there is a generic data, return it.
+//   - Else, if the byte slice is empty or is not a valid json,
+//     return it as a string.
+//   - Finally, if it is valid to be manipulated, then unmarshal it
+//     and return.
 func (r *ResponseBody) Unmarshal() interface{} {
 	if r.jsonValue != nil {
 		return r.jsonValue
@@ -147,25 +149,45 @@ type ResourceCacheControlValue struct {
 // ResourceCacheControl represent cache control directives
 // returned by upstream during statement resolution.
 type ResourceCacheControl struct {
-	NoCache bool
-	MaxAge  ResourceCacheControlValue
-	SMaxAge ResourceCacheControlValue
+	NoCache              bool
+	NoStore              bool
+	Private              bool
+	Public               bool
+	MustRevalidate       bool
+	MaxAge               ResourceCacheControlValue
+	SMaxAge              ResourceCacheControlValue
+	StaleWhileRevalidate ResourceCacheControlValue
+	StaleIfError         ResourceCacheControlValue
 }
 
 // DoneResource represents a statement result.
 type DoneResource struct {
-	Status          int
-	Success         bool
-	IgnoreErrors    bool
-	CacheControl    ResourceCacheControl
-	Method          string
-	URL             string
-	RequestParams   map[string]interface{}
-	RequestHeaders  map[string]string
-	RequestBody     interface{}
-	ResponseHeaders map[string]string
-	ResponseBody    *ResponseBody
-	ResponseTime    int64
+	Status            int
+	Success           bool
+	IgnoreErrors      bool
+	Skipped           bool
+	Hedged            bool
+	HedgeWon          bool
+	ErrorKind         string
+	ErrorMessage      string
+	CacheControl      ResourceCacheControl
+	ResourceName      string
+	Method            string
+	URL               string
+	RequestPathParams map[string]interface{}
+	RequestParams     map[string]interface{}
+	RequestHeaders    map[string]string
+	RequestBody       interface{}
+	ResponseHeaders   map[string]string
+	ResponseBody      *ResponseBody
+	ResponseTime      int64
+
+	ResponseSize           int
+	ResponseCompressedSize int
+
+	// RawResponseBody holds the upstream body before decoding,
+	// kept only for statements with the raw flag.
+	RawResponseBody *ResponseBody
 }
 
 // DoneResources represents a multiplexed statement result.